@@ -0,0 +1,218 @@
+// Package address converts between the forms of Lorenzo addresses: the bech32 account form
+// (lrz1...), the bech32 validator operator form (lrzvaloper1...) and the EIP-55 hex form (0x...)
+// used by the EVM. The conversions do not depend on the global bech32 config of the Cosmos SDK.
+package address
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	ethereum "github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	// AccountPrefix is the bech32 prefix of Lorenzo accounts
+	AccountPrefix = "lrz"
+	// ValidatorPrefix is the bech32 prefix of Lorenzo validator operators
+	ValidatorPrefix = "lrzvaloper"
+
+	// hexLength is the length in bytes of the addresses that have a hex form
+	hexLength = ethereum.AddressLength
+)
+
+var (
+	// ErrEmpty is returned for an empty address
+	ErrEmpty = errors.New("empty address")
+	// ErrInvalidBech32 is returned for an address that is not valid bech32
+	ErrInvalidBech32 = errors.New("invalid bech32 address")
+	// ErrWrongPrefix is returned for a bech32 address with an unexpected prefix
+	ErrWrongPrefix = errors.New("wrong bech32 prefix")
+	// ErrInvalidHex is returned for an address that is not a valid 0x hex address
+	ErrInvalidHex = errors.New("invalid hex address")
+	// ErrInvalidChecksum is returned for a mixed-case hex address with a wrong EIP-55 checksum
+	ErrInvalidChecksum = errors.New("invalid EIP-55 checksum")
+	// ErrInvalidLength is returned for an address of a length that has no hex form
+	ErrInvalidLength = errors.New("invalid address length")
+)
+
+// Addresses holds the forms of the address of an account
+type Addresses struct {
+	// Bech32 is the lrz1... form of the address
+	Bech32 string
+	// Hex is the 0x... EIP-55 form of the address
+	Hex string
+}
+
+// ParseAccount parses an account address in either lrz1... or 0x... form
+func ParseAccount(addr string) (sdk.AccAddress, error) {
+	if IsHex(addr) {
+		return ParseHex(addr)
+	}
+	return ParseBech32(addr)
+}
+
+// ParseBech32 parses an account address in lrz1... form
+func ParseBech32(addr string) (sdk.AccAddress, error) {
+	bz, err := parseBech32(addr, AccountPrefix)
+	return sdk.AccAddress(bz), err
+}
+
+// ParseValidator parses a validator operator address in lrzvaloper1... form
+func ParseValidator(addr string) (sdk.ValAddress, error) {
+	bz, err := parseBech32(addr, ValidatorPrefix)
+	return sdk.ValAddress(bz), err
+}
+
+// ParseHex parses an account address in 0x... form, the prefix being accepted in either case.
+// A mixed-case address must have a valid EIP-55 checksum, all-lowercase and all-uppercase
+// addresses are accepted as is.
+func ParseHex(addr string) (sdk.AccAddress, error) {
+	if addr == "" {
+		return nil, ErrEmpty
+	}
+	if !IsHex(addr) || !ethereum.IsHexAddress(addr) {
+		return nil, fmt.Errorf("%w %s: expected 0x followed by %d hex digits", ErrInvalidHex, addr, 2*hexLength)
+	}
+	hexAddr := ethereum.HexToAddress(addr)
+	digits := addr[2:]
+	if digits != strings.ToLower(digits) && digits != strings.ToUpper(digits) && hexAddr.Hex()[2:] != digits {
+		return nil, fmt.Errorf("%w of %s, expected %s", ErrInvalidChecksum, addr, hexAddr.Hex())
+	}
+	return sdk.AccAddress(hexAddr.Bytes()), nil
+}
+
+// IsHex tells whether addr looks like a 0x... or 0X... address rather than a bech32 one
+func IsHex(addr string) bool {
+	return strings.HasPrefix(addr, "0x") || strings.HasPrefix(addr, "0X")
+}
+
+// ValidateAccount returns an error if addr is neither a valid lrz1... nor a valid 0x... address
+func ValidateAccount(addr string) error {
+	_, err := ParseAccount(addr)
+	return err
+}
+
+// ValidateValidator returns an error if addr is not a valid lrzvaloper1... address
+func ValidateValidator(addr string) error {
+	_, err := ParseValidator(addr)
+	return err
+}
+
+// ToBech32 returns the lrz1... form of the account address bz
+func ToBech32(bz []byte) string {
+	return mustEncode(AccountPrefix, bz)
+}
+
+// ToValidator returns the lrzvaloper1... form of the address bz
+func ToValidator(bz []byte) string {
+	return mustEncode(ValidatorPrefix, bz)
+}
+
+// ToHex returns the 0x... EIP-55 form of the account address bz, which must be 20 bytes long
+func ToHex(bz []byte) (string, error) {
+	if len(bz) != hexLength {
+		return "", fmt.Errorf("%w: %d bytes, expected %d", ErrInvalidLength, len(bz), hexLength)
+	}
+	return ethereum.BytesToAddress(bz).Hex(), nil
+}
+
+// AnyToBech32 converts an account address in either lrz1... or 0x... form to the lrz1... form
+func AnyToBech32(addr string) (string, error) {
+	bz, err := ParseAccount(addr)
+	if err != nil {
+		return "", err
+	}
+	return ToBech32(bz), nil
+}
+
+// AnyToHex converts an account address in either lrz1... or 0x... form to the 0x... EIP-55 form
+func AnyToHex(addr string) (string, error) {
+	bz, err := ParseAccount(addr)
+	if err != nil {
+		return "", err
+	}
+	return ToHex(bz)
+}
+
+// Bech32ToHex converts an lrz1... address to the 0x... EIP-55 form
+func Bech32ToHex(addr string) (string, error) {
+	bz, err := ParseBech32(addr)
+	if err != nil {
+		return "", err
+	}
+	return ToHex(bz)
+}
+
+// HexToBech32 converts a 0x... address to the lrz1... form
+func HexToBech32(addr string) (string, error) {
+	bz, err := ParseHex(addr)
+	if err != nil {
+		return "", err
+	}
+	return ToBech32(bz), nil
+}
+
+// AccountToValidator converts an account address in either lrz1... or 0x... form
+// to the lrzvaloper1... form of the same operator
+func AccountToValidator(addr string) (string, error) {
+	bz, err := ParseAccount(addr)
+	if err != nil {
+		return "", err
+	}
+	return ToValidator(bz), nil
+}
+
+// ValidatorToAccount converts an lrzvaloper1... address to the lrz1... form of the operator account
+func ValidatorToAccount(addr string) (string, error) {
+	bz, err := ParseValidator(addr)
+	if err != nil {
+		return "", err
+	}
+	return ToBech32(bz), nil
+}
+
+// FromPubKey returns both forms of the address of the account with the given public key
+func FromPubKey(pubKey cryptotypes.PubKey) (Addresses, error) {
+	if pubKey == nil {
+		return Addresses{}, errors.New("nil public key")
+	}
+	bz := pubKey.Address().Bytes()
+	hexAddr, err := ToHex(bz)
+	if err != nil {
+		return Addresses{}, err
+	}
+	return Addresses{
+		Bech32: ToBech32(bz),
+		Hex:    hexAddr,
+	}, nil
+}
+
+func parseBech32(addr, prefix string) ([]byte, error) {
+	if addr == "" {
+		return nil, ErrEmpty
+	}
+	hrp, bz, err := bech32.DecodeAndConvert(addr)
+	if err != nil {
+		return nil, fmt.Errorf("%w %s: %v", ErrInvalidBech32, addr, err)
+	}
+	if hrp != prefix {
+		return nil, fmt.Errorf("%w of %s: expected %s, got %s", ErrWrongPrefix, addr, prefix, hrp)
+	}
+	if err := sdk.VerifyAddressFormat(bz); err != nil {
+		return nil, fmt.Errorf("%w %s: %v", ErrInvalidBech32, addr, err)
+	}
+	return bz, nil
+}
+
+func mustEncode(prefix string, bz []byte) string {
+	addr, err := bech32.ConvertAndEncode(prefix, bz)
+	if err != nil {
+		// only fails on a prefix or data too long for bech32, which cannot happen for an address
+		panic(err)
+	}
+	return addr
+}
@@ -0,0 +1,104 @@
+package address_test
+
+import (
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	"github.com/evmos/ethermint/crypto/ethsecp256k1"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Lorenzo-Protocol/lorenzo-sdk/v3/address"
+)
+
+func TestConversions(t *testing.T) {
+	bz := []byte{
+		0x5a, 0xae, 0xb6, 0x05, 0x3f, 0x3e, 0x94, 0xc9, 0xb9, 0xa0,
+		0x9f, 0x33, 0x66, 0x94, 0x35, 0xe7, 0xef, 0x1b, 0xea, 0xed,
+	}
+	hexAddr := "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed"
+
+	bech32Addr := address.ToBech32(bz)
+	valAddr := address.ToValidator(bz)
+	require.Regexp(t, "^lrz1", bech32Addr)
+	require.Regexp(t, "^lrzvaloper1", valAddr)
+	converted, err := address.ToHex(bz)
+	require.NoError(t, err)
+	require.Equal(t, hexAddr, converted)
+
+	converted, err = address.Bech32ToHex(bech32Addr)
+	require.NoError(t, err)
+	require.Equal(t, hexAddr, converted)
+	converted, err = address.HexToBech32(hexAddr)
+	require.NoError(t, err)
+	require.Equal(t, bech32Addr, converted)
+	converted, err = address.AccountToValidator(hexAddr)
+	require.NoError(t, err)
+	require.Equal(t, valAddr, converted)
+	converted, err = address.ValidatorToAccount(valAddr)
+	require.NoError(t, err)
+	require.Equal(t, bech32Addr, converted)
+
+	// the 0x prefix is accepted in either case, and converted to the lowercase one
+	for _, addr := range []string{bech32Addr, hexAddr, "0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed", "0X5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed", "0X5AAEB6053F3E94C9B9A09F33669435E7EF1BEAED"} {
+		parsed, err := address.ParseAccount(addr)
+		require.NoError(t, err)
+		require.Equal(t, bz, parsed.Bytes())
+		converted, err = address.AnyToBech32(addr)
+		require.NoError(t, err)
+		require.Equal(t, bech32Addr, converted)
+		converted, err = address.AnyToHex(addr)
+		require.NoError(t, err)
+		require.Equal(t, hexAddr, converted)
+	}
+}
+
+func TestValidation(t *testing.T) {
+	bech32Addr := address.ToBech32(make([]byte, 20))
+	cosmosAddr := "cosmos1qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqnrql8a"
+
+	testCases := []struct {
+		addr string
+		err  error
+	}{
+		{"", address.ErrEmpty},
+		{"lrz1invalid", address.ErrInvalidBech32},
+		{cosmosAddr, address.ErrWrongPrefix},
+		{address.ToValidator(make([]byte, 20)), address.ErrWrongPrefix},
+		{"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAe", address.ErrInvalidHex},
+		{"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAeD", address.ErrInvalidChecksum},
+		{"0X5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAeD", address.ErrInvalidChecksum},
+		{"0X5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAe", address.ErrInvalidHex},
+	}
+	for _, tc := range testCases {
+		require.ErrorIs(t, address.ValidateAccount(tc.addr), tc.err, tc.addr)
+	}
+	require.NoError(t, address.ValidateAccount(bech32Addr))
+	require.ErrorIs(t, address.ValidateValidator(bech32Addr), address.ErrWrongPrefix)
+
+	_, err := address.ToHex(make([]byte, 32))
+	require.ErrorIs(t, err, address.ErrInvalidLength)
+}
+
+func TestFromPubKey(t *testing.T) {
+	ethKey, err := ethsecp256k1.GenerateKey()
+	require.NoError(t, err)
+	addrs, err := address.FromPubKey(ethKey.PubKey())
+	require.NoError(t, err)
+	// the address of an eth_secp256k1 key is its Ethereum address
+	require.Equal(t, ethKey.PubKey().Address().Bytes(), mustParse(t, addrs.Hex))
+	require.Equal(t, addrs.Bech32, address.ToBech32(ethKey.PubKey().Address()))
+
+	cosmosKey := secp256k1.GenPrivKey()
+	addrs, err = address.FromPubKey(cosmosKey.PubKey())
+	require.NoError(t, err)
+	require.Equal(t, cosmosKey.PubKey().Address().Bytes(), mustParse(t, addrs.Bech32))
+
+	_, err = address.FromPubKey(nil)
+	require.Error(t, err)
+}
+
+func mustParse(t *testing.T, addr string) []byte {
+	parsed, err := address.ParseAccount(addr)
+	require.NoError(t, err)
+	return parsed
+}
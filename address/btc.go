@@ -0,0 +1,147 @@
+package address
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/txscript"
+)
+
+// Bitcoin networks
+const (
+	BTCMainnet = "mainnet"
+	BTCTestnet = "testnet"
+	BTCSignet  = "signet"
+	BTCRegtest = "regtest"
+)
+
+// BTCAddressType is the kind of output script a BTC address pays to
+type BTCAddressType string
+
+// BTC address types
+const (
+	P2PKH  BTCAddressType = "p2pkh"
+	P2SH   BTCAddressType = "p2sh"
+	P2WPKH BTCAddressType = "p2wpkh"
+	P2WSH  BTCAddressType = "p2wsh"
+	P2TR   BTCAddressType = "p2tr"
+)
+
+var (
+	// ErrInvalidBTCAddress is returned for a string that is not a BTC address of the expected network
+	ErrInvalidBTCAddress = errors.New("invalid BTC address")
+	// ErrUnsupportedBTCAddress is returned for a BTC address that is not of one of the BTC address types
+	ErrUnsupportedBTCAddress = errors.New("unsupported BTC address type")
+	// ErrUnknownBTCNetwork is returned for a network that is not one of the Bitcoin networks
+	ErrUnknownBTCNetwork = errors.New("unknown BTC network")
+)
+
+// btcNetworks are the networks a BTC address is tried against when no network is given. Signet
+// addresses are encoded like testnet ones, so the signet ones are reported as testnet addresses.
+var btcNetworks = []string{BTCMainnet, BTCTestnet, BTCRegtest}
+
+// BTCAddress is a decoded BTC address
+type BTCAddress struct {
+	// Address is the canonical encoding of the address, e.g. lower case for segwit addresses
+	Address string
+	Type    BTCAddressType
+	Network string
+	// ScriptPubKey is the output script paying to the address
+	ScriptPubKey []byte
+}
+
+// BTCNetParams returns the chain params of the given Bitcoin network
+func BTCNetParams(network string) (*chaincfg.Params, error) {
+	switch network {
+	case BTCMainnet:
+		return &chaincfg.MainNetParams, nil
+	case BTCTestnet:
+		return &chaincfg.TestNet3Params, nil
+	case BTCSignet:
+		return &chaincfg.SigNetParams, nil
+	case BTCRegtest:
+		return &chaincfg.RegressionNetParams, nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownBTCNetwork, network)
+	}
+}
+
+// ParseBTC decodes a P2PKH, P2SH, P2WPKH, P2WSH or P2TR address of the given Bitcoin network,
+// or of any of them if network is empty
+func ParseBTC(addr, network string) (*BTCAddress, error) {
+	if addr == "" {
+		return nil, ErrEmpty
+	}
+	if network != "" {
+		return parseBTC(addr, network)
+	}
+
+	var firstErr error
+	for _, network := range btcNetworks {
+		btcAddr, err := parseBTC(addr, network)
+		if err == nil {
+			return btcAddr, nil
+		}
+		// an unsupported address type is the same on every network
+		if errors.Is(err, ErrUnsupportedBTCAddress) {
+			return nil, err
+		}
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+	return nil, firstErr
+}
+
+// ValidateBTC checks that addr is a P2PKH, P2SH, P2WPKH, P2WSH or P2TR address of the given
+// Bitcoin network, or of any of them if network is empty
+func ValidateBTC(addr, network string) error {
+	_, err := ParseBTC(addr, network)
+	return err
+}
+
+func parseBTC(addr, network string) (*BTCAddress, error) {
+	params, err := BTCNetParams(network)
+	if err != nil {
+		return nil, err
+	}
+
+	decoded, err := btcutil.DecodeAddress(addr, params)
+	if err != nil {
+		return nil, fmt.Errorf("%w %s on %s: %v", ErrInvalidBTCAddress, addr, network, err)
+	}
+	// base58 addresses of another network may decode, but are not for this one
+	if !decoded.IsForNet(params) {
+		return nil, fmt.Errorf("%w %s: not a %s address", ErrInvalidBTCAddress, addr, network)
+	}
+
+	var addrType BTCAddressType
+	switch decoded.(type) {
+	case *btcutil.AddressPubKeyHash:
+		addrType = P2PKH
+	case *btcutil.AddressScriptHash:
+		addrType = P2SH
+	case *btcutil.AddressWitnessPubKeyHash:
+		addrType = P2WPKH
+	case *btcutil.AddressWitnessScriptHash:
+		addrType = P2WSH
+	case *btcutil.AddressTaproot:
+		addrType = P2TR
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedBTCAddress, addr)
+	}
+
+	script, err := txscript.PayToAddrScript(decoded)
+	if err != nil {
+		return nil, fmt.Errorf("%w %s: %v", ErrInvalidBTCAddress, addr, err)
+	}
+
+	return &BTCAddress{
+		Address:      decoded.EncodeAddress(),
+		Type:         addrType,
+		Network:      network,
+		ScriptPubKey: script,
+	}, nil
+}
@@ -0,0 +1,58 @@
+package address_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/Lorenzo-Protocol/lorenzo-sdk/v3/address"
+)
+
+func TestParseBTC(t *testing.T) {
+	for _, tc := range []struct {
+		addr     string
+		network  string
+		addrType address.BTCAddressType
+		script   string
+	}{
+		{"1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN2", address.BTCMainnet, address.P2PKH, "76a91477bff20c60e522dfaa3350c39b030a5d004e839a88ac"},
+		{"3J98t1WpEZ73CNmQviecrnyiWrnqRhWNLy", address.BTCMainnet, address.P2SH, "a914b472a266d0bd89c13706a4132ccfb16f7c3b9fcb87"},
+		{"BC1QW508D6QEJXTDG4Y5R3ZARVARY0C5XW7KV8F3T4", address.BTCMainnet, address.P2WPKH, "0014751e76e8199196d454941c45d1b3a323f1433bd6"},
+		{"tb1qrp33g0q5c5txsp9arysrx4k6zdkfs4nce4xj0gdcccefvpysxf3q0sl5k7", address.BTCTestnet, address.P2WSH, "00201863143c14c5166804bd19203356da136c985678cd4d27a1b8c6329604903262"},
+		{"bc1p0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7vqzk5jj0", address.BTCMainnet, address.P2TR, "512079be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798"},
+	} {
+		btcAddr, err := address.ParseBTC(tc.addr, tc.network)
+		require.NoError(t, err, tc.addr)
+		require.Equal(t, tc.addrType, btcAddr.Type, tc.addr)
+		require.Equal(t, tc.script, hex.EncodeToString(btcAddr.ScriptPubKey), tc.addr)
+
+		// the network is detected if none is given
+		btcAddr, err = address.ParseBTC(tc.addr, "")
+		require.NoError(t, err, tc.addr)
+		require.Equal(t, tc.network, btcAddr.Network, tc.addr)
+	}
+
+	// segwit addresses are returned in lower case
+	btcAddr, err := address.ParseBTC("BC1QW508D6QEJXTDG4Y5R3ZARVARY0C5XW7KV8F3T4", "")
+	require.NoError(t, err)
+	require.Equal(t, "bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4", btcAddr.Address)
+
+	// signet shares the encoding of testnet
+	_, err = address.ParseBTC("tb1qrp33g0q5c5txsp9arysrx4k6zdkfs4nce4xj0gdcccefvpysxf3q0sl5k7", address.BTCSignet)
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		addr    string
+		network string
+	}{
+		{"", ""},
+		{"1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN2", address.BTCTestnet},
+		{"bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4", address.BTCRegtest},
+		{"bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t5", ""},
+		{"not an address", ""},
+		{"1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN2", "litecoin"},
+	} {
+		require.Error(t, address.ValidateBTC(tc.addr, tc.network), tc.addr)
+	}
+}
@@ -0,0 +1,170 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/authz"
+	pv "github.com/cosmos/relayer/v2/relayer/provider"
+)
+
+// In authz mode, the client key acts as the grantee of a granter account: every tx helper
+// wraps its msgs in a MsgExec signed by the client key, and the msgs are executed on behalf of
+// the granter, which must be their signer and must have granted their msg types to the client key.
+// The granter is set by the authz-granter field of the config, SetAuthzGranter or WithAuthzGranter.
+
+// MsgTypeURLs returns the type URLs of msgs, e.g. to grant them with GrantGenericAuthorizations
+func MsgTypeURLs(msgs ...sdk.Msg) []string {
+	typeURLs := make([]string, 0, len(msgs))
+	for _, msg := range msgs {
+		typeURLs = append(typeURLs, sdk.MsgTypeURL(msg))
+	}
+	return typeURLs
+}
+
+// SetAuthzGranter makes the client execute all its txs on behalf of granter, an empty granter disables authz mode
+func (c *Client) SetAuthzGranter(granter string) {
+	c.authzGranter.Store(granter)
+}
+
+// AuthzGranter returns the account the client executes its txs on behalf of, or an empty string
+func (c *Client) AuthzGranter() string {
+	granter, _ := c.authzGranter.Load().(string)
+	return granter
+}
+
+// authzGranterOf returns the granter the tx with the given options is executed on behalf of
+func (c *Client) authzGranterOf(options TxOptions) string {
+	if options.NoAuthz {
+		return ""
+	}
+	if options.AuthzGranter != "" {
+		return options.AuthzGranter
+	}
+	return c.AuthzGranter()
+}
+
+// wrapAuthz wraps msgs in a MsgExec signed by the key with the given name when the tx is executed
+// on behalf of a granter, and returns msgs as is otherwise
+func (c *Client) wrapAuthz(keyName string, msgs []sdk.Msg, options TxOptions) ([]sdk.Msg, error) {
+	granter := c.authzGranterOf(options)
+	if granter == "" {
+		return msgs, nil
+	}
+	granterAddr, err := sdk.GetFromBech32(granter, c.provider.PCfg.AccountPrefix)
+	if err != nil {
+		return nil, fmt.Errorf("invalid authz granter %s: %w", granter, err)
+	}
+	grantee, err := c.keyAddress(keyName)
+	if err != nil {
+		return nil, err
+	}
+
+	done := c.provider.SetSDKContext()
+	defer done()
+
+	anys := make([]*codectypes.Any, 0, len(msgs))
+	for _, msg := range msgs {
+		// msgs signed by the grantee itself need no authorization
+		for _, signer := range msg.GetSigners() {
+			if !bytes.Equal(signer, granterAddr) && signer.String() != grantee {
+				return nil, fmt.Errorf("%s is signed by %s instead of the authz granter %s", sdk.MsgTypeURL(msg), signer, granter)
+			}
+		}
+		msgAny, err := codectypes.NewAnyWithValue(msg)
+		if err != nil {
+			return nil, err
+		}
+		anys = append(anys, msgAny)
+	}
+
+	return []sdk.Msg{&authz.MsgExec{
+		Grantee: grantee,
+		Msgs:    anys,
+	}}, nil
+}
+
+// GrantAuthorization grants authorization from the client key to grantee, until expiration if not nil
+func (c *Client) GrantAuthorization(ctx context.Context, grantee string, authorization authz.Authorization, expiration *time.Time, opts ...TxOption) (*pv.RelayerTxResponse, error) {
+	msg, err := c.newMsgGrant(grantee, authorization, expiration, opts)
+	if err != nil {
+		return nil, err
+	}
+	return c.ReliablySendMsg(ctx, msg, nil, nil, append(opts, WithoutAuthz())...)
+}
+
+// GrantGenericAuthorizations grants grantee the execution of any msg with one of the given type URLs
+// on behalf of the client key, until expiration if not nil. See MsgTypeURLs.
+func (c *Client) GrantGenericAuthorizations(ctx context.Context, grantee string, msgTypeURLs []string, expiration *time.Time, opts ...TxOption) (*pv.RelayerTxResponse, error) {
+	msgs := make([]sdk.Msg, 0, len(msgTypeURLs))
+	for _, msgTypeURL := range msgTypeURLs {
+		msg, err := c.newMsgGrant(grantee, authz.NewGenericAuthorization(msgTypeURL), expiration, opts)
+		if err != nil {
+			return nil, err
+		}
+		msgs = append(msgs, msg)
+	}
+	return c.ReliablySendMsgs(ctx, msgs, nil, nil, append(opts, WithoutAuthz())...)
+}
+
+// RevokeAuthorizations revokes the authorizations of the given msg type URLs the client key granted to grantee
+func (c *Client) RevokeAuthorizations(ctx context.Context, grantee string, msgTypeURLs []string, opts ...TxOption) (*pv.RelayerTxResponse, error) {
+	granter, err := c.signerAddress(&authz.MsgRevoke{}, newTxOptions(opts...))
+	if err != nil {
+		return nil, err
+	}
+	msgs := make([]sdk.Msg, 0, len(msgTypeURLs))
+	for _, msgTypeURL := range msgTypeURLs {
+		msgs = append(msgs, &authz.MsgRevoke{
+			Granter:    granter,
+			Grantee:    grantee,
+			MsgTypeUrl: msgTypeURL,
+		})
+	}
+	return c.ReliablySendMsgs(ctx, msgs, nil, nil, append(opts, WithoutAuthz())...)
+}
+
+// GranteeAuthorizations returns the authorizations granter gave to the client key, restricted to
+// the given msg type URL if not empty. The grantee is the key signing the msgs of that type,
+// as resolved by the key routes and the WithSigner option.
+func (c *Client) GranteeAuthorizations(ctx context.Context, granter, msgTypeURL string, opts ...TxOption) ([]*authz.Grant, error) {
+	var msgTypeURLs []string
+	if msgTypeURL != "" {
+		msgTypeURLs = []string{msgTypeURL}
+	}
+	keyName, err := c.routedKey(msgTypeURLs, newTxOptions(opts...))
+	if err != nil {
+		return nil, err
+	}
+	grantee, err := c.keyAddress(keyName)
+	if err != nil {
+		return nil, err
+	}
+
+	var grants []*authz.Grant
+	err = c.WalkAuthzGrants(ctx, granter, grantee, msgTypeURL, func(grant *authz.Grant) error {
+		grants = append(grants, grant)
+		return nil
+	})
+	return grants, err
+}
+
+func (c *Client) newMsgGrant(grantee string, authorization authz.Authorization, expiration *time.Time, opts []TxOption) (*authz.MsgGrant, error) {
+	granter, err := c.signerAddress(&authz.MsgGrant{}, newTxOptions(opts...))
+	if err != nil {
+		return nil, err
+	}
+	msg := &authz.MsgGrant{
+		Granter: granter,
+		Grantee: grantee,
+		Grant:   authz.Grant{Expiration: expiration},
+	}
+	if err := msg.SetAuthorization(authorization); err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
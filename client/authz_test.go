@@ -0,0 +1,129 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	btclctypes "github.com/Lorenzo-Protocol/lorenzo/v3/x/btclightclient/types"
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/libs/bytes"
+	rpcclient "github.com/cometbft/cometbft/rpc/client"
+	coretypes "github.com/cometbft/cometbft/rpc/core/types"
+	"github.com/cosmos/cosmos-sdk/crypto/hd"
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	"github.com/cosmos/cosmos-sdk/x/authz"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Lorenzo-Protocol/lorenzo-sdk/v3/query"
+)
+
+func TestWrapAuthz(t *testing.T) {
+	c := newOfflineTestClient(t)
+	c.provider.PCfg.Key = "relayer"
+	_, _, err := c.provider.Keybase.NewMnemonic("relayer", keyring.English, sdk.FullFundraiserPath, keyring.DefaultBIP39Passphrase, hd.Secp256k1)
+	require.NoError(t, err)
+	grantee, err := c.GetAddr()
+	require.NoError(t, err)
+
+	granter, err := bech32.ConvertAndEncode("lrz", []byte("granter_address_____"))
+	require.NoError(t, err)
+	other, err := bech32.ConvertAndEncode("lrz", []byte("other_address_______"))
+	require.NoError(t, err)
+
+	msg := &btclctypes.MsgInsertHeaders{Signer: granter}
+
+	// without granter, msgs are sent as they are
+	msgs, err := c.wrapAuthz("relayer", []sdk.Msg{msg}, TxOptions{})
+	require.NoError(t, err)
+	require.Equal(t, []sdk.Msg{msg}, msgs)
+
+	c.SetAuthzGranter(granter)
+	msgs, err = c.wrapAuthz("relayer", []sdk.Msg{msg, &btclctypes.MsgInsertHeaders{Signer: grantee}}, TxOptions{})
+	require.NoError(t, err)
+	require.Len(t, msgs, 1)
+	exec, ok := msgs[0].(*authz.MsgExec)
+	require.True(t, ok)
+	require.Equal(t, grantee, exec.Grantee)
+	inner, err := exec.GetMessages()
+	require.NoError(t, err)
+	require.Len(t, inner, 2)
+	require.Equal(t, msg, inner[0])
+
+	// a msg signed by another account cannot be executed on behalf of the granter
+	_, err = c.wrapAuthz("relayer", []sdk.Msg{&btclctypes.MsgInsertHeaders{Signer: other}}, TxOptions{})
+	require.Error(t, err)
+
+	// the granter can be overridden or disabled per tx
+	msgs, err = c.wrapAuthz("relayer", []sdk.Msg{&btclctypes.MsgInsertHeaders{Signer: other}}, newTxOptions(WithAuthzGranter(other)))
+	require.NoError(t, err)
+	require.IsType(t, &authz.MsgExec{}, msgs[0])
+	msgs, err = c.wrapAuthz("relayer", []sdk.Msg{msg}, newTxOptions(WithoutAuthz()))
+	require.NoError(t, err)
+	require.Equal(t, []sdk.Msg{msg}, msgs)
+}
+
+// fakeGrantsNode is a node with no authz grants, recording the grantees it is queried for
+type fakeGrantsNode struct {
+	rpcclient.Client
+	grantees []string
+}
+
+func (f *fakeGrantsNode) ABCIQueryWithOptions(_ context.Context, path string, data bytes.HexBytes, _ rpcclient.ABCIQueryOptions) (*coretypes.ResultABCIQuery, error) {
+	if path != "/cosmos.authz.v1beta1.Query/Grants" {
+		return nil, fmt.Errorf("unexpected query %s", path)
+	}
+	var req authz.QueryGrantsRequest
+	if err := req.Unmarshal(data); err != nil {
+		return nil, err
+	}
+	f.grantees = append(f.grantees, req.Grantee)
+
+	bz, err := (&authz.QueryGrantsResponse{}).Marshal()
+	if err != nil {
+		return nil, err
+	}
+	return &coretypes.ResultABCIQuery{Response: abci.ResponseQuery{Value: bz, Height: 1}}, nil
+}
+
+func TestGranteeAuthorizations(t *testing.T) {
+	c := newOfflineTestClient(t)
+	addrs := map[string]string{}
+	for _, keyName := range []string{"btc", "staking"} {
+		_, err := c.CreateKey(keyName)
+		require.NoError(t, err)
+		addrs[keyName], err = c.keyAddress(keyName)
+		require.NoError(t, err)
+	}
+	require.NoError(t, c.SetActiveKey("staking"))
+	btcMsgType := sdk.MsgTypeURL(&btclctypes.MsgInsertHeaders{})
+	require.NoError(t, c.SetKeyRoute(btcMsgType, "btc"))
+
+	node := &fakeGrantsNode{}
+	var err error
+	c.QueryClient, err = query.NewWithClient(node, time.Second)
+	require.NoError(t, err)
+	granter, err := bech32.ConvertAndEncode("lrz", []byte("granter_address_____"))
+	require.NoError(t, err)
+
+	// the grantee is the key signing the msgs of the type, as for the txs executing them
+	for _, tc := range []struct {
+		msgTypeURL string
+		opts       []TxOption
+		grantee    string
+	}{
+		{btcMsgType, nil, addrs["btc"]},
+		{sdk.MsgTypeURL(&btclctypes.MsgUpdateParams{}), nil, addrs["staking"]},
+		{"", nil, addrs["staking"]},
+		{btcMsgType, []TxOption{WithSigner("staking")}, addrs["staking"]},
+	} {
+		node.grantees = nil
+		grants, err := c.GranteeAuthorizations(context.Background(), granter, tc.msgTypeURL, tc.opts...)
+		require.NoError(t, err)
+		require.Empty(t, grants)
+		require.Equal(t, []string{tc.grantee}, node.grantees, tc.msgTypeURL)
+	}
+}
@@ -0,0 +1,355 @@
+package client
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/mempool"
+	rpcclient "github.com/cometbft/cometbft/rpc/client"
+	coretypes "github.com/cometbft/cometbft/rpc/core/types"
+	sdkclient "github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/tx"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	txtypes "github.com/cosmos/cosmos-sdk/types/tx"
+	authsigning "github.com/cosmos/cosmos-sdk/x/auth/signing"
+	"github.com/cosmos/relayer/v2/relayer/chains/cosmos"
+	pv "github.com/cosmos/relayer/v2/relayer/provider"
+	"go.uber.org/zap"
+
+	"github.com/Lorenzo-Protocol/lorenzo-sdk/v3/query"
+)
+
+// broadcastMsgs builds a tx out of msgs, signs it with the key of seqs and broadcasts it,
+// returning once the tx has been checked by the node with the returned sequence.
+// A tx rejected by the node fails with a TxError. The account sequence of the tx is handed out by the sequence manager of the key:
+// on an account sequence mismatch, the txs the node lost are rebroadcast, or the sequence
+// is reset to the one expected by the node, and the tx is signed again.
+func (c *Client) broadcastMsgs(ctx context.Context, seqs *sequenceManager, msgs []sdk.Msg, options TxOptions) (*coretypes.ResultBroadcastTx, uint64, error) {
+	res, sequence, err := c.signAndBroadcast(ctx, seqs, msgs, options)
+	if err != nil {
+		seqs.stats.recordBroadcast("", err)
+	} else {
+		seqs.stats.recordBroadcast(res.Hash.String(), nil)
+	}
+	return res, sequence, err
+}
+
+// signAndBroadcast estimates the gas of msgs, then signs them with a sequence reserved from
+// seqs and broadcasts them once the txs of the previous sequences are accepted. A tx whose
+// sequence is invalidated by a previous tx failing meanwhile is signed again.
+func (c *Client) signAndBroadcast(ctx context.Context, seqs *sequenceManager, msgs []sdk.Msg, options TxOptions) (*coretypes.ResultBroadcastTx, uint64, error) {
+	gas, err := c.estimateGas(ctx, seqs, msgs, options)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var (
+		r          reservation
+		txBytes    []byte
+		gapChecked bool
+	)
+	for {
+		if txBytes == nil {
+			if r, err = seqs.reserve(); err != nil {
+				return nil, 0, err
+			}
+			if txBytes, err = c.buildSignedTx(seqs.keyName, r, gas, msgs, options); err != nil {
+				seqs.release(r)
+				return nil, 0, err
+			}
+		}
+
+		ok, err := seqs.waitTurn(ctx, r)
+		if err != nil {
+			seqs.release(r)
+			return nil, 0, err
+		}
+		if !ok {
+			txBytes = nil
+			continue
+		}
+
+		res, err := c.RPCClient.BroadcastTxSync(ctx, txBytes)
+		if err == nil && res.Code != 0 {
+			err = newCheckTxError(res)
+		}
+		if err == nil {
+			seqs.commit(r, txBytes)
+			return res, r.sequence, nil
+		}
+
+		txErr, ok := AsTxError(err)
+		if !ok || gapChecked {
+			seqs.release(r)
+			return res, 0, err
+		}
+		expected, ok := txErr.ExpectedSequence()
+		if !ok {
+			seqs.release(r)
+			return res, 0, err
+		}
+		// the tx is broadcast again once the gap is filled, or signed again if the sequence was reset
+		c.logger.Debug("account sequence mismatch", zap.Uint64("expected", expected), zap.Uint64("sequence", r.sequence))
+		gapChecked = true
+		if err := c.fillSequenceGap(ctx, seqs, expected); err != nil {
+			seqs.release(r)
+			return nil, 0, err
+		}
+	}
+}
+
+// fillSequenceGap rebroadcasts the txs the node lost when it expects an earlier sequence
+// than the next one to broadcast. If one of them cannot be rebroadcast, the sequence is reset
+// to it, since the following txs cannot be included without it. The caller must hold the
+// turn of the sequence the node rejected.
+func (c *Client) fillSequenceGap(ctx context.Context, seqs *sequenceManager, expected uint64) error {
+	for i, txBytes := range seqs.gap(expected) {
+		sequence := expected + uint64(i)
+		res, err := c.RPCClient.BroadcastTxSync(ctx, txBytes)
+		if err == nil && res.Code != 0 {
+			err = newCheckTxError(res)
+		}
+		if err != nil && !isTxInMempool(err) {
+			if ctx.Err() != nil {
+				return err
+			}
+			c.logger.Error("failed to rebroadcast tx, resetting account sequence", zap.Uint64("sequence", sequence), zap.Error(err))
+			seqs.reset(sequence)
+			return nil
+		}
+		c.logger.Debug("rebroadcast tx", zap.Uint64("sequence", sequence))
+	}
+	return nil
+}
+
+// isTxInMempool tells whether a broadcast failed because the tx is already in the mempool
+func isTxInMempool(err error) bool {
+	return errors.Is(err, sdkerrors.ErrTxInMempoolCache) || strings.Contains(err.Error(), mempool.ErrTxInCache.Error())
+}
+
+// estimateGas returns the gas limit of a tx made of msgs signed with the key of seqs, which
+// options set or which is estimated by simulating the tx
+func (c *Client) estimateGas(ctx context.Context, seqs *sequenceManager, msgs []sdk.Msg, options TxOptions) (uint64, error) {
+	if options.GasLimit > 0 {
+		return options.GasLimit, nil
+	}
+	accountNumber, sequence, err := seqs.next()
+	if err != nil {
+		return 0, err
+	}
+	txf, _, err := c.prepareTx(ctx, seqs.keyName, accountNumber, sequence, msgs, options)
+	if err != nil {
+		return 0, err
+	}
+	return txf.Gas(), nil
+}
+
+// buildSignedTx builds a tx out of msgs with the given gas limit and signs it with the
+// given key and the sequence of r. It returns the encoded tx.
+func (c *Client) buildSignedTx(keyName string, r reservation, gas uint64, msgs []sdk.Msg, options TxOptions) ([]byte, error) {
+	txf, err := c.prepareFactory(r.accountNumber, r.sequence, options)
+	if err != nil {
+		return nil, err
+	}
+
+	done := c.provider.SetSDKContext()
+	defer done()
+
+	_, txBytes, err := c.signTx(txf.WithGas(gas), keyName, msgs, options)
+	return txBytes, err
+}
+
+// signTx builds a tx out of msgs with txf, signs it with the given key and encodes it.
+// The tx is signed in the legacy EIP-712 sign mode if options or the client say so.
+func (c *Client) signTx(txf tx.Factory, keyName string, msgs []sdk.Msg, options TxOptions) (authsigning.Tx, []byte, error) {
+	txb, err := txf.BuildUnsignedTx(msgs...)
+	if err != nil {
+		return nil, nil, err
+	}
+	if c.isEIP712(options) {
+		err = c.signEIP712(txb, keyName, txf.AccountNumber(), txf.Sequence())
+	} else {
+		err = tx.Sign(txf, keyName, txb, false)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	txBytes, err := c.provider.Cdc.TxConfig.TxEncoder()(txb.GetTx())
+	if err != nil {
+		return nil, nil, err
+	}
+	return txb.GetTx(), txBytes, nil
+}
+
+// prepareTx returns the factory of a tx made of msgs, with its gas limit and fee set according
+// to options, along with the simulation of the tx if it was needed to estimate the gas limit.
+// The sequence of the returned factory is the one the simulation was run with, see simulateNext.
+func (c *Client) prepareTx(ctx context.Context, keyName string, accountNumber, sequence uint64, msgs []sdk.Msg, options TxOptions) (tx.Factory, *txtypes.SimulateResponse, error) {
+	txf, err := c.prepareFactory(accountNumber, sequence, options)
+	if err != nil {
+		return tx.Factory{}, nil, err
+	}
+	if options.GasLimit > 0 {
+		return txf.WithGas(options.GasLimit), nil, nil
+	}
+
+	simRes, txf, err := c.simulateNext(ctx, txf, keyName, msgs)
+	if err != nil {
+		return tx.Factory{}, nil, err
+	}
+	gas, err := c.adjustGas(simRes.GasInfo.GasUsed, options)
+	if err != nil {
+		return tx.Factory{}, nil, err
+	}
+
+	return txf.WithGas(gas), simRes, nil
+}
+
+// prepareFactory returns the factory of a tx signed with the given account number and sequence,
+// with the gas and fee settings of the config overridden by options.
+// Unlike the provider's PrepareFactory, it does not query the account.
+func (c *Client) prepareFactory(accountNumber, sequence uint64, options TxOptions) (tx.Factory, error) {
+	done := c.provider.SetSDKContext()
+	defer done()
+
+	txf := c.provider.TxFactory().
+		WithAccountNumber(accountNumber).
+		WithSequence(sequence)
+	if c.provider.PCfg.MinGasAmount != 0 {
+		txf = txf.WithGas(c.provider.PCfg.MinGasAmount)
+	}
+	if options.GasAdjustment > 0 {
+		txf = txf.WithGasAdjustment(options.GasAdjustment)
+	}
+	if options.GasPrices != nil {
+		txf = txf.WithGasPrices(options.GasPrices.String())
+	}
+	if options.Fees != nil {
+		txf = txf.WithGasPrices("").WithFees(options.Fees.String())
+	}
+	feeGranter, err := c.feeGranterAddr(options)
+	if err != nil {
+		return txf, err
+	}
+	if feeGranter != nil {
+		txf = txf.WithFeeGranter(feeGranter)
+	}
+	return c.provider.SetWithExtensionOptions(txf)
+}
+
+// adjustGas multiplies the simulated gas by the gas adjustment of options or of the config,
+// bounded by the max gas amount of the config
+func (c *Client) adjustGas(gasUsed uint64, options TxOptions) (uint64, error) {
+	if options.GasAdjustment == 0 {
+		return c.provider.AdjustEstimatedGas(gasUsed)
+	}
+
+	gas := options.GasAdjustment * float64(gasUsed)
+	if math.IsInf(gas, 1) {
+		return 0, fmt.Errorf("infinite gas used")
+	}
+	if maxGas := c.provider.PCfg.MaxGasAmount; maxGas > 0 {
+		gas = math.Min(gas, float64(maxGas))
+	}
+	return uint64(gas), nil
+}
+
+// fetchAccount returns the account number and sequence of the given key from chain
+func (c *Client) fetchAccount(keyName string) (uint64, uint64, error) {
+	addr, err := c.provider.GetKeyAddressForKey(keyName)
+	if err != nil {
+		return 0, 0, err
+	}
+	return c.provider.GetAccountNumberSequence(sdkclient.Context{}, addr)
+}
+
+// simulateNext is like simulate, but simulates the tx again with the sequence the node expects
+// if it checked another one, e.g. because txs of the key were accepted meanwhile. It returns
+// the factory the simulation succeeded with.
+func (c *Client) simulateNext(ctx context.Context, txf tx.Factory, keyName string, msgs []sdk.Msg) (*txtypes.SimulateResponse, tx.Factory, error) {
+	simRes, err := c.simulate(ctx, txf, keyName, msgs)
+	if txErr, ok := AsTxError(err); ok {
+		if expected, ok := txErr.ExpectedSequence(); ok && expected != txf.Sequence() {
+			txf = txf.WithSequence(expected)
+			simRes, err = c.simulate(ctx, txf, keyName, msgs)
+		}
+	}
+	return simRes, txf, err
+}
+
+// simulate runs msgs in a tx built by txf against the latest state of the node.
+// A tx failing the simulation returns a TxError.
+func (c *Client) simulate(ctx context.Context, txf tx.Factory, keyName string, msgs []sdk.Msg) (*txtypes.SimulateResponse, error) {
+	keyInfo, err := c.provider.Keybase.Key(keyName)
+	if err != nil {
+		return nil, err
+	}
+
+	done := c.provider.SetSDKContext()
+	simReq, err := cosmos.BuildSimTx(keyInfo, txf, msgs...)
+	done()
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.RPCClient.ABCIQueryWithOptions(ctx, query.SimulatePath, simReq, rpcclient.DefaultABCIQueryOptions)
+	if err != nil {
+		return nil, err
+	}
+	if !res.Response.IsOK() {
+		return nil, newSimulationTxError(res.Response)
+	}
+
+	var simRes txtypes.SimulateResponse
+	if err := simRes.Unmarshal(res.Response.Value); err != nil {
+		return nil, err
+	}
+	if simRes.GasInfo == nil || simRes.Result == nil {
+		return nil, fmt.Errorf("incomplete simulation response")
+	}
+	return &simRes, nil
+}
+
+// stringifyEvents converts events to their string representation, keeping their order
+func stringifyEvents(events []abci.Event) []sdk.StringEvent {
+	stringEvents := make([]sdk.StringEvent, 0, len(events))
+	for _, event := range events {
+		stringEvents = append(stringEvents, sdk.StringifyEvent(event))
+	}
+	return stringEvents
+}
+
+// newRelayerTxResponse converts an included tx into the response returned by the tx helpers
+func newRelayerTxResponse(res *coretypes.ResultTx) *pv.RelayerTxResponse {
+	return &pv.RelayerTxResponse{
+		Height:    res.Height,
+		TxHash:    res.Hash.String(),
+		Codespace: res.TxResult.Codespace,
+		Code:      res.TxResult.Code,
+		Data:      strings.ToUpper(hex.EncodeToString(res.TxResult.Data)),
+		Events:    newRelayerEvents(res.TxResult.Events),
+	}
+}
+
+// newRelayerEvents converts events into the events of the response returned by the tx helpers
+func newRelayerEvents(events []abci.Event) []pv.RelayerEvent {
+	relayerEvents := make([]pv.RelayerEvent, 0, len(events))
+	for _, event := range events {
+		attributes := make(map[string]string, len(event.Attributes))
+		for _, attr := range event.Attributes {
+			attributes[attr.Key] = attr.Value
+		}
+		relayerEvents = append(relayerEvents, pv.RelayerEvent{
+			EventType:  event.Type,
+			Attributes: attributes,
+		})
+	}
+	return relayerEvents
+}
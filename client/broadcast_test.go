@@ -0,0 +1,201 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/libs/bytes"
+	rpcclient "github.com/cometbft/cometbft/rpc/client"
+	coretypes "github.com/cometbft/cometbft/rpc/core/types"
+	"github.com/cometbft/cometbft/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	txtypes "github.com/cosmos/cosmos-sdk/types/tx"
+	authsigning "github.com/cosmos/cosmos-sdk/x/auth/signing"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Lorenzo-Protocol/lorenzo-sdk/v3/query"
+)
+
+// fakeNode is a node checking the sequence of the txs it simulates and admits to its mempool.
+// Simulations of txs sending slowAmount wait for release to be closed, and broadcasts fail
+// with the errors of broadcastErrs, in order, before succeeding.
+type fakeNode struct {
+	rpcclient.Client
+	decoder sdk.TxDecoder
+
+	mu       sync.Mutex
+	sequence uint64
+	mempool  []types.Tx
+
+	slowAmount int64
+	slow       chan struct{}
+	release    chan struct{}
+
+	broadcastErrs []error
+	broadcasts    int
+}
+
+func newFakeNode(c *Client, sequence uint64) *fakeNode {
+	return &fakeNode{
+		decoder:  c.provider.Cdc.TxConfig.TxDecoder(),
+		sequence: sequence,
+		slow:     make(chan struct{}),
+		release:  make(chan struct{}),
+	}
+}
+
+// checkTx returns the code and log of the check of the sequence of a tx
+func (f *fakeNode) checkTx(txBytes []byte) (sdk.Tx, uint32, string, error) {
+	tx, err := f.decoder(txBytes)
+	if err != nil {
+		return nil, 0, "", err
+	}
+	sigs, err := tx.(authsigning.SigVerifiableTx).GetSignaturesV2()
+	if err != nil {
+		return nil, 0, "", err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if sigs[0].Sequence != f.sequence {
+		return tx, 32, fmt.Sprintf("account sequence mismatch, expected %d, got %d: incorrect account sequence", f.sequence, sigs[0].Sequence), nil
+	}
+	return tx, 0, "", nil
+}
+
+func (f *fakeNode) ABCIQueryWithOptions(_ context.Context, path string, data bytes.HexBytes, _ rpcclient.ABCIQueryOptions) (*coretypes.ResultABCIQuery, error) {
+	if path != query.SimulatePath {
+		return nil, fmt.Errorf("unexpected query %s", path)
+	}
+	var req txtypes.SimulateRequest
+	if err := req.Unmarshal(data); err != nil {
+		return nil, err
+	}
+	txBytes := req.TxBytes
+	if len(txBytes) == 0 {
+		// a Tx encodes the same way as the TxRaw of its encoded body and auth info
+		var err error
+		if txBytes, err = req.Tx.Marshal(); err != nil {
+			return nil, err
+		}
+	}
+	tx, code, log, err := f.checkTx(txBytes)
+	if err != nil {
+		return nil, err
+	}
+	if send, ok := tx.GetMsgs()[0].(*banktypes.MsgSend); ok && send.Amount.AmountOf("alrz").Int64() == f.slowAmount {
+		close(f.slow)
+		<-f.release
+	}
+	if code != 0 {
+		return &coretypes.ResultABCIQuery{Response: abci.ResponseQuery{Codespace: "sdk", Code: code, Log: log}}, nil
+	}
+
+	res := txtypes.SimulateResponse{
+		GasInfo: &sdk.GasInfo{GasUsed: 100000},
+		Result: &sdk.Result{
+			Data:   []byte{0xab},
+			Events: []abci.Event{{Type: "transfer", Attributes: []abci.EventAttribute{{Key: "amount", Value: "1alrz"}}}},
+		},
+	}
+	bz, err := res.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	return &coretypes.ResultABCIQuery{Response: abci.ResponseQuery{Value: bz}}, nil
+}
+
+func (f *fakeNode) BroadcastTxSync(_ context.Context, txBytes types.Tx) (*coretypes.ResultBroadcastTx, error) {
+	f.mu.Lock()
+	f.broadcasts++
+	if len(f.broadcastErrs) > 0 {
+		err := f.broadcastErrs[0]
+		f.broadcastErrs = f.broadcastErrs[1:]
+		f.mu.Unlock()
+		return nil, err
+	}
+	f.mu.Unlock()
+
+	_, code, log, err := f.checkTx(txBytes)
+	if err != nil {
+		return nil, err
+	}
+	if code == 0 {
+		f.mu.Lock()
+		f.sequence++
+		f.mempool = append(f.mempool, txBytes)
+		f.mu.Unlock()
+	}
+	return &coretypes.ResultBroadcastTx{Codespace: "sdk", Code: code, Log: log, Hash: txBytes.Hash()}, nil
+}
+
+// newBroadcastTestClient returns an offline client with a key whose account is at the given
+// sequence on a fake node
+func newBroadcastTestClient(t *testing.T, sequence uint64) (*Client, *fakeNode, *sequenceManager) {
+	c := newOfflineTestClient(t)
+	c.provider.PCfg.GasAdjustment = 1.5
+	c.provider.PCfg.GasPrices = "0alrz"
+	_, err := c.CreateKey("relayer")
+	require.NoError(t, err)
+	require.NoError(t, c.SetActiveKey("relayer"))
+
+	node := newFakeNode(c, sequence)
+	c.QueryClient, err = query.NewWithClient(node, time.Second)
+	require.NoError(t, err)
+
+	seqs := newSequenceManager(func() (uint64, uint64, error) {
+		return 1, sequence, nil
+	})
+	seqs.keyName = "relayer"
+	c.signers["relayer"] = seqs
+	return c, node, seqs
+}
+
+func TestBroadcastConcurrently(t *testing.T) {
+	c, node, seqs := newBroadcastTestClient(t, 5)
+	node.slowAmount = 1
+	addr, err := c.GetAddr()
+	require.NoError(t, err)
+	send := func(amount int64) []sdk.Msg {
+		return []sdk.Msg{banktypes.NewMsgSend(sdk.MustAccAddressFromBech32(addr), sdk.MustAccAddressFromBech32(addr), sdk.NewCoins(sdk.NewInt64Coin("alrz", amount)))}
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// a tx whose simulation is slow does not hold back the txs of the same key
+	type result struct {
+		sequence uint64
+		err      error
+	}
+	slow := make(chan result)
+	go func() {
+		_, sequence, err := c.broadcastMsgs(ctx, seqs, send(1), TxOptions{})
+		slow <- result{sequence, err}
+	}()
+	<-node.slow
+
+	_, sequence, err := c.broadcastMsgs(ctx, seqs, send(2), TxOptions{})
+	require.NoError(t, err)
+	require.Equal(t, uint64(5), sequence)
+
+	// and once simulated, it is signed with the next sequence
+	close(node.release)
+	res := <-slow
+	require.NoError(t, res.err)
+	require.Equal(t, uint64(6), res.sequence)
+	require.Len(t, node.mempool, 2)
+
+	// another signer used the key: the tx is signed again with the sequence of the node
+	node.sequence = 9
+	_, sequence, err = c.broadcastMsgs(ctx, seqs, send(3), TxOptions{GasLimit: 200000})
+	require.NoError(t, err)
+	require.Equal(t, uint64(9), sequence)
+
+	stats := seqs.stats.snapshot()
+	require.Equal(t, uint64(3), stats.Broadcast)
+}
@@ -2,13 +2,20 @@ package client
 
 import (
 	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	lorenzo "github.com/Lorenzo-Protocol/lorenzo/v3/app"
+	rpcclient "github.com/cometbft/cometbft/rpc/client"
 	"github.com/cosmos/relayer/v2/relayer/chains/cosmos"
 	"go.uber.org/zap"
+	"google.golang.org/grpc"
 
 	"github.com/Lorenzo-Protocol/lorenzo-sdk/v3/config"
+	"github.com/Lorenzo-Protocol/lorenzo-sdk/v3/event"
+	"github.com/Lorenzo-Protocol/lorenzo-sdk/v3/evm"
 	"github.com/Lorenzo-Protocol/lorenzo-sdk/v3/query"
 )
 
@@ -19,6 +26,23 @@ type Client struct {
 	timeout  time.Duration
 	logger   *zap.Logger
 	cfg      *config.LorenzoConfig
+
+	retryMu     sync.RWMutex
+	retryPolicy RetryPolicy
+
+	// keyMu guards the active key, the sequences of the signing keys and the key routes
+	keyMu     sync.RWMutex
+	signers   map[string]*sequenceManager
+	keyRoutes map[string]string
+
+	dryRun atomic.Bool
+	eip712 atomic.Bool
+
+	authzGranter atomic.Value
+	feeGranter   atomic.Value
+
+	evm          *evm.Client
+	eventDecoder *event.Decoder
 }
 
 func New(cfg *config.LorenzoConfig, logger *zap.Logger) (*Client, error) {
@@ -70,26 +94,131 @@ func New(cfg *config.LorenzoConfig, logger *zap.Logger) (*Client, error) {
 		return nil, err
 	}
 
+	// spread the RPC calls of both queries and txs over all endpoints if several are configured.
+	// The provider client is connected to the first endpoint, so the pool reuses it for that one.
+	// The pool is started once the client is ready, its health probes recovering failed endpoints.
+	if endpoints := cfg.RPCEndpoints(); len(endpoints) > 1 {
+		clients := []rpcclient.Client{cp.RPCClient}
+		for _, addr := range endpoints[1:] {
+			rpcClient, err := query.NewRPCClient(addr, cfg.Timeout)
+			if err != nil {
+				return nil, err
+			}
+			clients = append(clients, rpcClient)
+		}
+		pool, err := query.NewEndpointPoolWithClients(endpoints, clients, query.EndpointPoolOptions{
+			Selection:           cfg.RPCSelection,
+			MaxHeightLag:        cfg.RPCMaxHeightLag,
+			HealthCheckInterval: cfg.RPCHealthCheckInterval,
+		})
+		if err != nil {
+			return nil, err
+		}
+		cp.RPCClient = pool
+	}
+
+	// module queries go through gRPC when a gRPC address is configured
+	var grpcConn *grpc.ClientConn
+	if cfg.GRPCAddr != "" {
+		grpcConn, err = query.DialGRPC(cfg.GRPCAddr, cfg.GRPCTLS)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// create a queryClient so that the Client inherits all query functions
-	queryClient, err := query.NewWithClient(cp.RPCClient, cfg.Timeout)
+	queryClient, err := query.NewWithClients(cp.RPCClient, grpcConn, cfg.Timeout)
 	if err != nil {
 		return nil, err
 	}
 
-	return &Client{
-		queryClient,
-		cp,
-		cfg.Timeout,
-		zapLogger,
-		cfg,
-	}, nil
+	c := &Client{
+		QueryClient:  queryClient,
+		provider:     cp,
+		timeout:      cfg.Timeout,
+		logger:       zapLogger,
+		cfg:          cfg,
+		retryPolicy:  retryPolicyFromConfig(cfg),
+		eventDecoder: event.NewDecoder(encCfg.InterfaceRegistry),
+	}
+	// the sequences of a key are only set up once it signs, so that the keys of the config
+	// may be created after the client, and query-only clients need no key at all
+	c.signers = map[string]*sequenceManager{}
+	c.keyRoutes = map[string]string{}
+	for msgTypeURL, keyName := range cfg.KeyRoutes {
+		if err := c.SetKeyRoute(msgTypeURL, keyName); err != nil {
+			return nil, fmt.Errorf("invalid route of %s: %w", msgTypeURL, err)
+		}
+	}
+	c.dryRun.Store(cfg.DryRun)
+	c.eip712.Store(cfg.SignModeStr == config.SignModeEIP712)
+	c.authzGranter.Store(cfg.AuthzGranter)
+	c.feeGranter.Store(cfg.FeeGranter)
+
+	if cfg.EVMRPCAddr != "" {
+		if c.evm, err = evm.New(cfg.EVMRPCAddr, cfg.Timeout); err != nil {
+			return nil, err
+		}
+	}
+
+	if pool, ok := cp.RPCClient.(*query.EndpointPool); ok {
+		if err := pool.Start(); err != nil {
+			return nil, err
+		}
+	}
+
+	return c, nil
 }
 
 func (c *Client) GetConfig() *config.LorenzoConfig {
 	return c.cfg
 }
 
+// EventDecoder returns the decoder of the events of the Lorenzo modules, backed by the interface registry of the app
+func (c *Client) EventDecoder() *event.Decoder {
+	return c.eventDecoder
+}
+
+// EventStream returns a stream of the decoded events of the chain, which follows new blocks through
+// the RPC client and backfills the blocks missed across reconnections. The stream starts the RPC client
+// for its subscription, which Stop stops. The stream logs with the logger of the client and bounds its
+// calls by the timeout of the config unless opts say otherwise.
+func (c *Client) EventStream(opts event.StreamOptions) *event.Stream {
+	if opts.Logger == nil {
+		opts.Logger = c.logger
+	}
+	if opts.Timeout == 0 {
+		opts.Timeout = c.timeout
+	}
+	return event.NewStream(c.RPCClient, c.eventDecoder, opts)
+}
+
+// BlockScanner returns a scanner handing the mint and burn events of the chain over from the
+// checkpoint saved in store, with the same defaults as EventStream and the btc-network of the config
+func (c *Client) BlockScanner(store event.CheckpointStore, opts event.ScannerOptions) *event.Scanner {
+	if opts.Logger == nil {
+		opts.Logger = c.logger
+	}
+	if opts.Timeout == 0 {
+		opts.Timeout = c.timeout
+	}
+	if opts.BTCNetwork == "" {
+		opts.BTCNetwork = c.cfg.BTCNetwork
+	}
+	return event.NewScanner(c.RPCClient, c.eventDecoder, store, opts)
+}
+
 func (c *Client) Stop() error {
+	if c.evm != nil {
+		c.evm.Close()
+	}
+
+	if c.GRPCConn != nil {
+		if err := c.GRPCConn.Close(); err != nil {
+			return err
+		}
+	}
+
 	if !c.provider.RPCClient.IsRunning() {
 		return nil
 	}
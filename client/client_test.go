@@ -0,0 +1,137 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	rpcclient "github.com/cometbft/cometbft/rpc/client"
+	coretypes "github.com/cometbft/cometbft/rpc/core/types"
+	"github.com/cometbft/cometbft/types"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/Lorenzo-Protocol/lorenzo-sdk/v3/config"
+	"github.com/Lorenzo-Protocol/lorenzo-sdk/v3/event"
+	"github.com/Lorenzo-Protocol/lorenzo-sdk/v3/query"
+)
+
+// newTestConfig returns the config of a client with a test keyring and no node behind its RPC address
+func newTestConfig(t *testing.T) *config.LorenzoConfig {
+	return &config.LorenzoConfig{
+		ChainID:        "lorenzo_83291-1",
+		RPCAddr:        "http://127.0.0.1:1",
+		AccountPrefix:  "lrz",
+		KeyringBackend: "test",
+		KeyDirectory:   t.TempDir(),
+		GasAdjustment:  1.5,
+		GasPrices:      "0alrz",
+		Timeout:        time.Second,
+	}
+}
+
+func TestNewEndpointPool(t *testing.T) {
+	cfg := newTestConfig(t)
+	cfg.RPCAddrs = []string{"http://127.0.0.1:2"}
+
+	// the pool runs even though no node is up, and stops with the client
+	c, err := New(cfg, zap.NewNop())
+	require.NoError(t, err)
+	pool, ok := c.RPCClient.(*query.EndpointPool)
+	require.True(t, ok)
+	require.True(t, pool.IsRunning())
+	require.Equal(t, []string{"http://127.0.0.1:1", "http://127.0.0.1:2"}, []string{pool.Endpoints()[0].Addr, pool.Endpoints()[1].Addr})
+	require.NoError(t, c.Stop())
+	require.False(t, pool.IsRunning())
+}
+
+// fakeStreamNode is a node that only notifies new blocks to its subscribers once started
+type fakeStreamNode struct {
+	rpcclient.Client
+
+	mu      sync.Mutex
+	running bool
+	height  int64
+	subs    map[string]chan coretypes.ResultEvent
+}
+
+func (f *fakeStreamNode) Start() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.running = true
+	return nil
+}
+
+func (f *fakeStreamNode) IsRunning() bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.running
+}
+
+func (f *fakeStreamNode) Status(context.Context) (*coretypes.ResultStatus, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return &coretypes.ResultStatus{SyncInfo: coretypes.SyncInfo{LatestBlockHeight: f.height}}, nil
+}
+
+func (f *fakeStreamNode) BlockResults(_ context.Context, height *int64) (*coretypes.ResultBlockResults, error) {
+	return &coretypes.ResultBlockResults{Height: *height}, nil
+}
+
+func (f *fakeStreamNode) Subscribe(_ context.Context, subscriber, _ string, _ ...int) (<-chan coretypes.ResultEvent, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if !f.running {
+		return nil, errors.New("subscribe is not supported by a stopped client")
+	}
+	out := make(chan coretypes.ResultEvent, 10)
+	f.subs[subscriber] = out
+	return out, nil
+}
+
+func (f *fakeStreamNode) UnsubscribeAll(_ context.Context, subscriber string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.subs, subscriber)
+	return nil
+}
+
+// produce adds a block to the chain and notifies the subscribers, returning whether there were any
+func (f *fakeStreamNode) produce() bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.height++
+	for _, out := range f.subs {
+		out <- coretypes.ResultEvent{Data: types.EventDataNewBlockHeader{Header: types.Header{Height: f.height}}}
+	}
+	return len(f.subs) > 0
+}
+
+func TestEventStream(t *testing.T) {
+	node := &fakeStreamNode{height: 1, subs: map[string]chan coretypes.ResultEvent{}}
+	c := newOfflineTestClient(t)
+	c.timeout = time.Second
+	c.eventDecoder = event.NewDecoder(c.provider.Cdc.InterfaceRegistry)
+	var err error
+	c.QueryClient, err = query.NewWithClient(node, time.Second)
+	require.NoError(t, err)
+
+	// the stream starts the RPC client to subscribe, as it would otherwise never poll for new blocks
+	stream := c.EventStream(event.StreamOptions{PollInterval: time.Hour})
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	blocks, errs := stream.Subscribe(ctx, 0)
+
+	require.Eventually(t, node.produce, 5*time.Second, 10*time.Millisecond)
+	require.True(t, node.IsRunning())
+	select {
+	case block := <-blocks:
+		require.GreaterOrEqual(t, block.Height, int64(2))
+	case err := <-errs:
+		t.Fatal(err)
+	case <-ctx.Done():
+		t.Fatal("no block streamed")
+	}
+}
@@ -0,0 +1,115 @@
+package client
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/cometbft/cometbft/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	pv "github.com/cosmos/relayer/v2/relayer/provider"
+	"go.uber.org/zap"
+)
+
+// DryRunResult is the outcome of a tx that was signed and simulated against the node,
+// but not broadcast
+type DryRunResult struct {
+	SimulationResult
+	// TxHash is the hash the tx would have if it was broadcast
+	TxHash string
+	// TxBytes is the signed tx
+	TxBytes []byte
+}
+
+// relayerTxResponse returns the response the tx helpers return for a dry run:
+// it has no height, and its data and events are the ones of the simulation
+func (r *DryRunResult) relayerTxResponse() *pv.RelayerTxResponse {
+	return &pv.RelayerTxResponse{
+		TxHash: r.TxHash,
+		Data:   strings.ToUpper(hex.EncodeToString(r.Result.Data)),
+		Events: newRelayerEvents(r.Result.Events),
+	}
+}
+
+// SetDryRun enables or disables the dry-run mode of the client. In dry-run mode, txs are
+// validated, signed and simulated but never broadcast: the tx helpers return the would-be
+// events of the simulation, and BroadcastMsgs the would-be hash of the tx.
+func (c *Client) SetDryRun(dryRun bool) {
+	c.dryRun.Store(dryRun)
+}
+
+// IsDryRun returns whether the client is in dry-run mode
+func (c *Client) IsDryRun() bool {
+	return c.dryRun.Load()
+}
+
+// isDryRun returns whether a tx sent with options must be dry run
+func (c *Client) isDryRun(options TxOptions) bool {
+	return options.DryRun || c.IsDryRun()
+}
+
+// DryRun runs ValidateBasic on msgs, then signs a tx made of them with the client key and simulates
+// it against the node, without broadcasting it. A tx failing the simulation returns a TxError.
+func (c *Client) DryRun(ctx context.Context, msgs []sdk.Msg, opts ...TxOption) (*DryRunResult, error) {
+	return c.dryRunMsgs(ctx, msgs, newTxOptions(opts...))
+}
+
+func (c *Client) dryRunMsgs(ctx context.Context, msgs []sdk.Msg, options TxOptions) (*DryRunResult, error) {
+	if len(msgs) == 0 {
+		return nil, fmt.Errorf("empty message set provided")
+	}
+	for _, msg := range msgs {
+		if err := msg.ValidateBasic(); err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", sdk.MsgTypeURL(msg), err)
+		}
+	}
+	seqs, err := c.signerFor(msgs, options)
+	if err != nil {
+		return nil, err
+	}
+	if msgs, err = c.wrapAuthz(seqs.keyName, msgs, options); err != nil {
+		return nil, err
+	}
+
+	// the simulation checks the sequence of the tx against the mempool state of the node,
+	// so the tx is signed with the sequence the node expects next without reserving it
+	accountNumber, sequence, err := seqs.next()
+	if err != nil {
+		return nil, err
+	}
+
+	keyName := seqs.keyName
+	txf, simRes, err := c.prepareTx(ctx, keyName, accountNumber, sequence, msgs, options)
+	if err != nil {
+		return nil, c.wrapFeeGrantError(err, options)
+	}
+	if simRes == nil {
+		// the gas limit was given, but the events of the tx are still wanted
+		if simRes, txf, err = c.simulateNext(ctx, txf, keyName, msgs); err != nil {
+			return nil, c.wrapFeeGrantError(err, options)
+		}
+	}
+
+	done := c.provider.SetSDKContext()
+	signedTx, txBytes, err := c.signTx(txf, keyName, msgs, options)
+	done()
+	if err != nil {
+		return nil, err
+	}
+
+	result := &DryRunResult{
+		SimulationResult: SimulationResult{
+			GasUsed:  simRes.GasInfo.GasUsed,
+			GasLimit: signedTx.GetGas(),
+			Fee:      signedTx.GetFee(),
+			Events:   stringifyEvents(simRes.Result.Events),
+			Result:   simRes.Result,
+		},
+		TxHash:  fmt.Sprintf("%X", types.Tx(txBytes).Hash()),
+		TxBytes: txBytes,
+	}
+	c.logger.Info("dry run, tx not broadcast", zap.String("tx_hash", result.TxHash), zap.Uint64("gas_used", result.GasUsed))
+
+	return result, nil
+}
@@ -0,0 +1,82 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/cometbft/cometbft/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDryRun(t *testing.T) {
+	c, node, seqs := newBroadcastTestClient(t, 5)
+	addr, err := c.GetAddr()
+	require.NoError(t, err)
+	send := func(amount int64) []sdk.Msg {
+		return []sdk.Msg{banktypes.NewMsgSend(sdk.MustAccAddressFromBech32(addr), sdk.MustAccAddressFromBech32(addr), sdk.Coins{sdk.NewInt64Coin("alrz", amount)})}
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	for _, tc := range []struct {
+		name     string
+		dryRun   bool
+		opts     []TxOption
+		gasLimit uint64
+	}{
+		{"option", false, []TxOption{WithDryRun()}, 150000},
+		{"client", true, nil, 150000},
+		{"given gas limit", true, []TxOption{WithGasLimit(300000)}, 300000},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			c.SetDryRun(tc.dryRun)
+			defer c.SetDryRun(false)
+			require.Equal(t, tc.dryRun, c.IsDryRun())
+			opts := append([]TxOption{}, tc.opts...)
+
+			// the tx is signed with the next sequence and simulated
+			res, err := c.DryRun(ctx, send(1), opts...)
+			require.NoError(t, err)
+			require.Equal(t, uint64(100000), res.GasUsed)
+			require.Equal(t, tc.gasLimit, res.GasLimit)
+			require.Equal(t, fmt.Sprintf("%X", types.Tx(res.TxBytes).Hash()), res.TxHash)
+			require.Len(t, res.Events, 1)
+			require.Equal(t, "transfer", res.Events[0].Type)
+			signedTx, err := c.DecodeTx(res.TxBytes)
+			require.NoError(t, err)
+			sigs, err := signedTx.GetSignaturesV2()
+			require.NoError(t, err)
+			require.Len(t, sigs, 1)
+			require.Equal(t, uint64(5), sigs[0].Sequence)
+
+			// and the tx helpers return what they would have sent, without broadcasting it
+			rlyRes, err := c.ReliablySendMsgs(ctx, send(1), nil, nil, append(opts, WithDryRun())...)
+			require.NoError(t, err)
+			require.Equal(t, res.TxHash, rlyRes.TxHash)
+			require.Zero(t, rlyRes.Height)
+			require.Equal(t, "AB", rlyRes.Data)
+			require.Len(t, rlyRes.Events, 1)
+			require.Equal(t, map[string]string{"amount": "1alrz"}, rlyRes.Events[0].Attributes)
+			hash, err := c.BroadcastMsgs(ctx, send(1), append(opts, WithDryRun())...)
+			require.NoError(t, err)
+			require.Equal(t, res.TxHash, hash)
+			require.Empty(t, node.mempool)
+		})
+	}
+
+	// invalid msgs are rejected before being simulated
+	_, err = c.DryRun(ctx, send(0))
+	require.ErrorContains(t, err, "invalid /cosmos.bank.v1beta1.MsgSend")
+	_, err = c.DryRun(ctx, nil)
+	require.Error(t, err)
+
+	// the dry runs reserved no sequence
+	_, sequence, err := c.broadcastMsgs(ctx, seqs, send(1), TxOptions{})
+	require.NoError(t, err)
+	require.Equal(t, uint64(5), sequence)
+	require.Len(t, node.mempool, 1)
+}
@@ -0,0 +1,191 @@
+package client
+
+import (
+	"fmt"
+
+	sdkclient "github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/codec"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
+	"github.com/cosmos/cosmos-sdk/x/auth/migrations/legacytx"
+	authsigning "github.com/cosmos/cosmos-sdk/x/auth/signing"
+	authtx "github.com/cosmos/cosmos-sdk/x/auth/tx"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/evmos/ethermint/ethereum/eip712"
+	ethermint "github.com/evmos/ethermint/types"
+)
+
+// In the legacy EIP-712 sign mode of Ethermint, an eth_secp256k1 key signs the amino JSON sign bytes
+// of the tx as EIP-712 typed data, as a wallet like MetaMask does. The signature is carried by an
+// ExtensionOptionsWeb3Tx, and the tx signature itself is left empty in the amino JSON sign mode.
+// All the msgs of such a tx must have the same type, since the typed data is derived from the first one.
+
+// eip712Codec unpacks the msgs of the typed data, as the EIP-712 ante handler of the chain does
+var eip712Codec codec.ProtoCodecMarshaler
+
+func init() {
+	registry := codectypes.NewInterfaceRegistry()
+	ethermint.RegisterInterfaces(registry)
+	eip712Codec = codec.NewProtoCodec(registry)
+}
+
+// SetEIP712 enables or disables the legacy EIP-712 sign mode for all the txs of the client,
+// which requires eth_secp256k1 signing keys
+func (c *Client) SetEIP712(enabled bool) {
+	c.eip712.Store(enabled)
+}
+
+// IsEIP712 returns whether the client signs its txs in the legacy EIP-712 sign mode
+func (c *Client) IsEIP712() bool {
+	return c.eip712.Load()
+}
+
+// isEIP712 returns whether a tx sent with options must be signed in the legacy EIP-712 sign mode
+func (c *Client) isEIP712(options TxOptions) bool {
+	return options.EIP712 || c.IsEIP712()
+}
+
+// EIP712TypedData returns the EIP-712 typed data the signer of unsignedTx signs in the legacy
+// EIP-712 sign mode, with the given account number and sequence. Marshalled as JSON, it is
+// the payload a wallet displays and signs with eth_signTypedData_v4.
+func (c *Client) EIP712TypedData(unsignedTx sdk.Tx, accountNumber, sequence uint64) (apitypes.TypedData, error) {
+	signingTx, err := c.toSigningTx(unsignedTx)
+	if err != nil {
+		return apitypes.TypedData{}, err
+	}
+
+	done := c.provider.SetSDKContext()
+	defer done()
+
+	typedData, _, err := c.eip712TypedData(signingTx, accountNumber, sequence)
+	return typedData, err
+}
+
+// SignTxEIP712Offline is like SignTxOffline but signs unsignedTx in the legacy EIP-712 sign mode,
+// replacing its signatures and extension options
+func (c *Client) SignTxEIP712Offline(keyName string, unsignedTx sdk.Tx, accountNumber, sequence uint64) (authsigning.Tx, error) {
+	txb, err := c.wrapTx(unsignedTx)
+	if err != nil {
+		return nil, err
+	}
+
+	done := c.provider.SetSDKContext()
+	defer done()
+
+	if err := c.signEIP712(txb, keyName, accountNumber, sequence); err != nil {
+		return nil, err
+	}
+	return txb.GetTx(), nil
+}
+
+// signEIP712 signs the tx of txb with the given key in the legacy EIP-712 sign mode.
+// The caller must hold the SDK context.
+func (c *Client) signEIP712(txb sdkclient.TxBuilder, keyName string, accountNumber, sequence uint64) error {
+	record, err := c.provider.Keybase.Key(keyName)
+	if err != nil {
+		return fmt.Errorf("failed to get key %s: %w", keyName, err)
+	}
+	pubKey, err := record.GetPubKey()
+	if err != nil {
+		return err
+	}
+	if pubKey.Type() != KeyAlgoEthSecp256k1 {
+		return fmt.Errorf("EIP-712 signing requires an %s key, %s is a %s key", KeyAlgoEthSecp256k1, keyName, pubKey.Type())
+	}
+
+	typedData, chainID, err := c.eip712TypedData(txb.GetTx(), accountNumber, sequence)
+	if err != nil {
+		return err
+	}
+	if signer := txb.GetTx().GetSigners()[0]; !signer.Equals(sdk.AccAddress(pubKey.Address())) {
+		return fmt.Errorf("key %s is not the signer %s of the tx", keyName, signer)
+	}
+	sigHash, _, err := apitypes.TypedDataAndHash(typedData)
+	if err != nil {
+		return fmt.Errorf("failed to hash the EIP-712 typed data: %w", err)
+	}
+
+	// eth_secp256k1 keys sign a 32 bytes hash as is
+	sig, _, err := c.provider.Keybase.Sign(keyName, sigHash)
+	if err != nil {
+		return err
+	}
+	// use the recovery id of Ethereum wallets
+	sig[ethcrypto.RecoveryIDOffset] += 27
+
+	extTxb, ok := txb.(authtx.ExtensionOptionsTxBuilder)
+	if !ok {
+		return fmt.Errorf("tx builder of type %T cannot hold extension options", txb)
+	}
+	option, err := codectypes.NewAnyWithValue(&ethermint.ExtensionOptionsWeb3Tx{
+		TypedDataChainID: chainID,
+		FeePayer:         sdk.AccAddress(pubKey.Address()).String(),
+		FeePayerSig:      sig,
+	})
+	if err != nil {
+		return err
+	}
+	extTxb.SetExtensionOptions(option)
+
+	return txb.SetSignatures(signing.SignatureV2{
+		PubKey: pubKey,
+		Data: &signing.SingleSignatureData{
+			SignMode: signing.SignMode_SIGN_MODE_LEGACY_AMINO_JSON,
+		},
+		Sequence: sequence,
+	})
+}
+
+// eip712TypedData returns the typed data of signingTx and the EIP-155 chain ID it is signed for.
+// The caller must hold the SDK context.
+func (c *Client) eip712TypedData(signingTx authsigning.Tx, accountNumber, sequence uint64) (apitypes.TypedData, uint64, error) {
+	msgs := signingTx.GetMsgs()
+	if err := validateEIP712Msgs(msgs); err != nil {
+		return apitypes.TypedData{}, 0, err
+	}
+	signers := signingTx.GetSigners()
+	if len(signers) != 1 {
+		return apitypes.TypedData{}, 0, fmt.Errorf("EIP-712 txs have a single signer, got %d", len(signers))
+	}
+
+	chainID, err := ethermint.ParseChainID(c.provider.PCfg.ChainID)
+	if err != nil {
+		return apitypes.TypedData{}, 0, fmt.Errorf("invalid chain ID %s: %w", c.provider.PCfg.ChainID, err)
+	}
+
+	signBytes := legacytx.StdSignBytes(
+		c.provider.PCfg.ChainID,
+		accountNumber,
+		sequence,
+		signingTx.GetTimeoutHeight(),
+		legacytx.StdFee{Amount: signingTx.GetFee(), Gas: signingTx.GetGas()},
+		msgs,
+		signingTx.GetMemo(),
+		signingTx.GetTip(),
+	)
+	typedData, err := eip712.LegacyWrapTxToTypedData(eip712Codec, chainID.Uint64(), msgs[0], signBytes, &eip712.FeeDelegationOptions{
+		FeePayer: signers[0],
+	})
+	if err != nil {
+		return apitypes.TypedData{}, 0, fmt.Errorf("failed to build the EIP-712 typed data: %w", err)
+	}
+	return typedData, chainID.Uint64(), nil
+}
+
+// validateEIP712Msgs checks that msgs can be signed in the legacy EIP-712 sign mode
+func validateEIP712Msgs(msgs []sdk.Msg) error {
+	if len(msgs) == 0 {
+		return fmt.Errorf("empty message set provided")
+	}
+	for _, msg := range msgs {
+		if _, ok := msg.(legacytx.LegacyMsg); !ok {
+			return fmt.Errorf("%s does not support amino JSON signing", sdk.MsgTypeURL(msg))
+		}
+		if typeURL := sdk.MsgTypeURL(msg); typeURL != sdk.MsgTypeURL(msgs[0]) {
+			return fmt.Errorf("EIP-712 txs are made of msgs of a single type, got %s and %s", sdk.MsgTypeURL(msgs[0]), typeURL)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package client
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	sdkmath "cosmossdk.io/math"
+	tokentypes "github.com/Lorenzo-Protocol/lorenzo/v3/x/token/types"
+	"github.com/cosmos/cosmos-sdk/crypto/hd"
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authsigning "github.com/cosmos/cosmos-sdk/x/auth/signing"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/evmos/ethermint/app/ante"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignEIP712(t *testing.T) {
+	c := newOfflineTestClient(t)
+
+	key, err := c.CreateKey("relayer", WithMnemonic(testMnemonic))
+	require.NoError(t, err)
+	sender, err := sdk.AccAddressFromBech32(key.Address)
+	require.NoError(t, err)
+
+	msg := &tokentypes.MsgConvertERC20{
+		ContractAddress: "0x80b5a32E4F032B2a058b4F29EC95EEfEEB87aDcd",
+		Amount:          sdkmath.NewInt(100),
+		Receiver:        key.Address,
+		Sender:          common.BytesToAddress(sender).Hex(),
+	}
+	unsignedTx, err := c.BuildUnsignedTx([]sdk.Msg{msg}, OfflineTxParams{
+		AccountNumber: 7,
+		Sequence:      3,
+		GasLimit:      200000,
+		Fees:          sdk.NewCoins(sdk.NewInt64Coin("alrz", 4000000000000000)),
+	})
+	require.NoError(t, err)
+
+	// the payload a wallet would sign
+	typedData, err := c.EIP712TypedData(unsignedTx, 7, 3)
+	require.NoError(t, err)
+	require.Equal(t, "Cosmos Web3", typedData.Domain.Name)
+	require.Equal(t, int64(83291), (*big.Int)(typedData.Domain.ChainId).Int64())
+	payload, err := json.Marshal(typedData)
+	require.NoError(t, err)
+	require.Contains(t, string(payload), key.Address)
+
+	signedTx, err := c.SignTxEIP712Offline("relayer", unsignedTx, 7, 3)
+	require.NoError(t, err)
+	resigned, err := c.SignTxEIP712Offline("relayer", unsignedTx, 7, 4)
+	require.NoError(t, err)
+
+	// the signature passes the EIP-712 verification of the chain, but not for another sequence
+	done := c.provider.SetSDKContext()
+	sigs, err := signedTx.GetSignaturesV2()
+	require.NoError(t, err)
+	require.Len(t, sigs, 1)
+	signerData := authsigning.SignerData{ChainID: c.provider.PCfg.ChainID, AccountNumber: 7, Sequence: 3}
+	require.NoError(t, ante.VerifySignature(sigs[0].PubKey, signerData, sigs[0].Data, nil, signedTx))
+	require.Error(t, ante.VerifySignature(sigs[0].PubKey, signerData, sigs[0].Data, nil, resigned))
+	done()
+
+	// Cosmos SDK keys cannot sign EIP-712 txs
+	_, _, err = c.provider.Keybase.NewMnemonic("cosmos", keyring.English, sdk.FullFundraiserPath, keyring.DefaultBIP39Passphrase, hd.Secp256k1)
+	require.NoError(t, err)
+	_, err = c.SignTxEIP712Offline("cosmos", unsignedTx, 7, 3)
+	require.Error(t, err)
+
+	// msgs of different types cannot be signed together
+	send := banktypes.NewMsgSend(sender, sender, sdk.NewCoins(sdk.NewInt64Coin("alrz", 1)))
+	mixedTx, err := c.BuildUnsignedTx([]sdk.Msg{msg, send}, OfflineTxParams{GasLimit: 200000})
+	require.NoError(t, err)
+	_, err = c.EIP712TypedData(mixedTx, 7, 3)
+	require.Error(t, err)
+}
@@ -0,0 +1,101 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+
+	errorsmod "cosmossdk.io/errors"
+	abci "github.com/cometbft/cometbft/abci/types"
+	coretypes "github.com/cometbft/cometbft/rpc/core/types"
+)
+
+var accountSeqRegex = regexp.MustCompile("account sequence mismatch, expected ([0-9]+), got ([0-9]+)")
+
+// TxError is returned when the node rejects a tx, either when simulating it,
+// when checking it before admitting it to the mempool or when executing it in a block.
+// It matches the error registered for its codespace and code with errors.Is, e.g.
+//
+//	errors.Is(err, sdkerrors.ErrWrongSequence)
+//	errors.Is(err, btcstakingtypes.ErrDupBTCTx)
+type TxError struct {
+	// Codespace and Code identify the error registered by the module that failed the tx
+	Codespace string
+	Code      uint32
+	// TxHash is empty if the tx failed during simulation
+	TxHash string
+	// Height is 0 unless the tx was executed in a block
+	Height    int64
+	GasWanted int64
+	GasUsed   int64
+	RawLog    string
+}
+
+func (e *TxError) Error() string {
+	if e.TxHash == "" {
+		return fmt.Sprintf("tx failed with code %d in codespace %s: %s", e.Code, e.Codespace, e.RawLog)
+	}
+	return fmt.Sprintf("tx %s failed with code %d in codespace %s: %s", e.TxHash, e.Code, e.Codespace, e.RawLog)
+}
+
+// Unwrap returns the error registered for the codespace and code of the tx,
+// or an error matching no registered error if there is none
+func (e *TxError) Unwrap() error {
+	return errorsmod.ABCIError(e.Codespace, e.Code, e.RawLog)
+}
+
+// ExpectedSequence returns the account sequence expected by the node
+// if the tx failed because of an account sequence mismatch
+func (e *TxError) ExpectedSequence() (uint64, bool) {
+	matches := accountSeqRegex.FindStringSubmatch(e.RawLog)
+	if len(matches) == 0 {
+		return 0, false
+	}
+	seq, err := strconv.ParseUint(matches[1], 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return seq, true
+}
+
+// AsTxError returns the TxError in err's chain, if any
+func AsTxError(err error) (*TxError, bool) {
+	var txErr *TxError
+	if errors.As(err, &txErr) {
+		return txErr, true
+	}
+	return nil, false
+}
+
+// newSimulationTxError returns the TxError of a failed tx simulation
+func newSimulationTxError(res abci.ResponseQuery) *TxError {
+	return &TxError{
+		Codespace: res.Codespace,
+		Code:      res.Code,
+		RawLog:    res.Log,
+	}
+}
+
+// newCheckTxError returns the TxError of a tx rejected from the mempool
+func newCheckTxError(res *coretypes.ResultBroadcastTx) *TxError {
+	return &TxError{
+		Codespace: res.Codespace,
+		Code:      res.Code,
+		TxHash:    res.Hash.String(),
+		RawLog:    res.Log,
+	}
+}
+
+// newDeliverTxError returns the TxError of a tx that failed in a block
+func newDeliverTxError(res *coretypes.ResultTx) *TxError {
+	return &TxError{
+		Codespace: res.TxResult.Codespace,
+		Code:      res.TxResult.Code,
+		TxHash:    res.Hash.String(),
+		Height:    res.Height,
+		GasWanted: res.TxResult.GasWanted,
+		GasUsed:   res.TxResult.GasUsed,
+		RawLog:    res.TxResult.Log,
+	}
+}
@@ -0,0 +1,34 @@
+package client_test
+
+import (
+	"errors"
+	"testing"
+
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Lorenzo-Protocol/lorenzo-sdk/v3/client"
+)
+
+func TestTxError(t *testing.T) {
+	var err error = &client.TxError{
+		Codespace: sdkerrors.ErrWrongSequence.Codespace(),
+		Code:      sdkerrors.ErrWrongSequence.ABCICode(),
+		TxHash:    "ABCD",
+		RawLog:    "account sequence mismatch, expected 12, got 10: incorrect account sequence",
+	}
+
+	require.ErrorIs(t, err, sdkerrors.ErrWrongSequence)
+	require.False(t, errors.Is(err, sdkerrors.ErrInsufficientFee))
+
+	txErr, ok := client.AsTxError(err)
+	require.True(t, ok)
+	seq, ok := txErr.ExpectedSequence()
+	require.True(t, ok)
+	require.Equal(t, uint64(12), seq)
+
+	txErr = &client.TxError{Codespace: "sdk", Code: sdkerrors.ErrInsufficientFee.ABCICode()}
+	require.ErrorIs(t, txErr, sdkerrors.ErrInsufficientFee)
+	_, ok = txErr.ExpectedSequence()
+	require.False(t, ok)
+}
@@ -0,0 +1,123 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/Lorenzo-Protocol/lorenzo-sdk/v3/address"
+	"github.com/Lorenzo-Protocol/lorenzo-sdk/v3/event"
+	"github.com/Lorenzo-Protocol/lorenzo-sdk/v3/evm"
+)
+
+// EVM returns the client of the Ethereum JSON-RPC of the node, or nil if the config has no evm-rpc-addr
+func (c *Client) EVM() *evm.Client {
+	return c.evm
+}
+
+// EVMSigner returns a signer of EVM txs backed by the key of the keyring with the given name,
+// or by the active key if keyName is empty. The key must be an eth_secp256k1 key.
+func (c *Client) EVMSigner(keyName string) (*evm.KeyringSigner, error) {
+	if keyName == "" {
+		keyName = c.ActiveKey()
+	}
+	return evm.NewKeyringSigner(c.provider.Keybase, keyName)
+}
+
+// TokenPairContract returns the address of the ERC20 contract of the token pair of the given denom or contract
+func (c *Client) TokenPairContract(ctx context.Context, tokenAddressOrDenom string) (common.Address, error) {
+	resp, err := c.TokenPairWithContext(ctx, tokenAddressOrDenom)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to query the token pair of %s: %w", tokenAddressOrDenom, err)
+	}
+	contract := resp.TokenPair.ContractAddress
+	if !common.IsHexAddress(contract) {
+		return common.Address{}, fmt.Errorf("invalid contract address %s of the token pair of %s", contract, tokenAddressOrDenom)
+	}
+	return common.HexToAddress(contract), nil
+}
+
+// TokenPairAsset returns the asset describing how the amounts of the token pair of the given denom or
+// contract scale from a source chain with the given decimals, the decimals of the token being read
+// from its ERC20 contract
+func (c *Client) TokenPairAsset(ctx context.Context, tokenAddressOrDenom string, sourceDecimals uint32) (event.Asset, error) {
+	evmClient, err := c.evmClient()
+	if err != nil {
+		return event.Asset{}, err
+	}
+	resp, err := c.TokenPairWithContext(ctx, tokenAddressOrDenom)
+	if err != nil {
+		return event.Asset{}, fmt.Errorf("failed to query the token pair of %s: %w", tokenAddressOrDenom, err)
+	}
+	if !common.IsHexAddress(resp.TokenPair.ContractAddress) {
+		return event.Asset{}, fmt.Errorf("invalid contract address %s of the token pair of %s", resp.TokenPair.ContractAddress, tokenAddressOrDenom)
+	}
+	decimals, err := evmClient.Decimals(ctx, common.HexToAddress(resp.TokenPair.ContractAddress))
+	if err != nil {
+		return event.Asset{}, fmt.Errorf("failed to read the decimals of %s: %w", resp.TokenPair.ContractAddress, err)
+	}
+	return event.Asset{
+		Denom:          resp.TokenPair.Denom,
+		SourceDecimals: sourceDecimals,
+		TargetDecimals: uint32(decimals),
+	}, nil
+}
+
+// ERC20Balance returns the balance of the account with the given lrz1... or 0x... address
+// in the ERC20 contract of the token pair of the given denom or contract
+func (c *Client) ERC20Balance(ctx context.Context, tokenAddressOrDenom, account string) (*big.Int, error) {
+	evmClient, err := c.evmClient()
+	if err != nil {
+		return nil, err
+	}
+	owner, err := evmAddress(account)
+	if err != nil {
+		return nil, err
+	}
+	token, err := c.TokenPairContract(ctx, tokenAddressOrDenom)
+	if err != nil {
+		return nil, err
+	}
+	return evmClient.BalanceOf(ctx, token, owner)
+}
+
+// ERC20Allowance returns the amount spender can spend on behalf of owner in the ERC20 contract
+// of the token pair of the given denom or contract, both accounts being lrz1... or 0x... addresses
+func (c *Client) ERC20Allowance(ctx context.Context, tokenAddressOrDenom, owner, spender string) (*big.Int, error) {
+	evmClient, err := c.evmClient()
+	if err != nil {
+		return nil, err
+	}
+	ownerAddr, err := evmAddress(owner)
+	if err != nil {
+		return nil, err
+	}
+	spenderAddr, err := evmAddress(spender)
+	if err != nil {
+		return nil, err
+	}
+	token, err := c.TokenPairContract(ctx, tokenAddressOrDenom)
+	if err != nil {
+		return nil, err
+	}
+	return evmClient.Allowance(ctx, token, ownerAddr, spenderAddr)
+}
+
+// evmClient returns the client of the Ethereum JSON-RPC of the node, or an error if there is none
+func (c *Client) evmClient() (*evm.Client, error) {
+	if c.evm == nil {
+		return nil, fmt.Errorf("no evm-rpc-addr configured")
+	}
+	return c.evm, nil
+}
+
+// evmAddress parses a lrz1... or 0x... account address as an EVM address
+func evmAddress(account string) (common.Address, error) {
+	addr, err := address.ParseAccount(account)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return common.BytesToAddress(addr), nil
+}
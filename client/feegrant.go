@@ -0,0 +1,177 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/feegrant"
+	"github.com/cosmos/gogoproto/proto"
+	pv "github.com/cosmos/relayer/v2/relayer/provider"
+)
+
+// SetFeeGranter makes a fee allowance of granter pay the fees of all the txs of the client,
+// an empty granter makes the client key pay them again
+func (c *Client) SetFeeGranter(granter string) {
+	c.feeGranter.Store(granter)
+}
+
+// FeeGranter returns the account paying the fees of the txs of the client, or an empty string
+func (c *Client) FeeGranter() string {
+	granter, _ := c.feeGranter.Load().(string)
+	return granter
+}
+
+// feeGranterOf returns the granter paying the fees of the tx with the given options
+func (c *Client) feeGranterOf(options TxOptions) string {
+	if options.NoFeeGrant {
+		return ""
+	}
+	if options.FeeGranter != "" {
+		return options.FeeGranter
+	}
+	return c.FeeGranter()
+}
+
+// feeGranterAddr returns the address of the granter paying the fees of the tx with the given options, if any
+func (c *Client) feeGranterAddr(options TxOptions) (sdk.AccAddress, error) {
+	granter := c.feeGranterOf(options)
+	if granter == "" {
+		return nil, nil
+	}
+	addr, err := sdk.GetFromBech32(granter, c.provider.PCfg.AccountPrefix)
+	if err != nil {
+		return nil, fmt.Errorf("invalid fee granter %s: %w", granter, err)
+	}
+	return addr, nil
+}
+
+// isFeeGrantError tells whether a tx failed because the fee allowance paying its fees cannot be used
+func isFeeGrantError(err error) bool {
+	return errors.Is(err, feegrant.ErrNoAllowance) ||
+		errors.Is(err, feegrant.ErrFeeLimitExceeded) ||
+		errors.Is(err, feegrant.ErrFeeLimitExpired) ||
+		errors.Is(err, feegrant.ErrMessageNotAllowed)
+}
+
+// wrapFeeGrantError explains why the fee allowance paying the fees of the tx with the given options
+// cannot be used, and returns other errors as is
+func (c *Client) wrapFeeGrantError(err error, options TxOptions) error {
+	granter := c.feeGranterOf(options)
+	if err == nil || granter == "" || !isFeeGrantError(err) {
+		return err
+	}
+
+	var reason string
+	switch {
+	case errors.Is(err, feegrant.ErrFeeLimitExceeded):
+		reason = "is exhausted"
+	case errors.Is(err, feegrant.ErrFeeLimitExpired):
+		reason = "has expired"
+	case errors.Is(err, feegrant.ErrMessageNotAllowed):
+		reason = "does not allow the msgs of the tx"
+	default:
+		// the allowance is removed once exhausted or expired
+		reason = "does not exist, or was exhausted or expired"
+	}
+	return fmt.Errorf("fee allowance of %s %s: %w", granter, reason, err)
+}
+
+// FeeGrantAllowance returns the fee allowance the fee granter of the client gives to the client key,
+// failing if there is none or if it has expired as of the latest block. The grantee is the key
+// signing the msgs of the given type URL, as resolved by the key routes and the WithSigner option,
+// and the granter the one of the WithFeeGranter option if set.
+func (c *Client) FeeGrantAllowance(ctx context.Context, msgTypeURL string, opts ...TxOption) (feegrant.FeeAllowanceI, error) {
+	options := newTxOptions(opts...)
+	granter := c.feeGranterOf(options)
+	if granter == "" {
+		return nil, fmt.Errorf("no fee granter is set")
+	}
+	var msgTypeURLs []string
+	if msgTypeURL != "" {
+		msgTypeURLs = []string{msgTypeURL}
+	}
+	keyName, err := c.routedKey(msgTypeURLs, options)
+	if err != nil {
+		return nil, err
+	}
+	grantee, err := c.keyAddress(keyName)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.FeeAllowanceWithContext(ctx, granter, grantee)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query the fee allowance of %s to %s: %w", granter, grantee, err)
+	}
+	if resp.Allowance == nil {
+		return nil, fmt.Errorf("%s gives no fee allowance to %s: %w", granter, grantee, feegrant.ErrNoAllowance)
+	}
+	var allowance feegrant.FeeAllowanceI
+	if err := c.provider.Cdc.InterfaceRegistry.UnpackAny(resp.Allowance.Allowance, &allowance); err != nil {
+		return nil, err
+	}
+
+	expiration, err := allowance.ExpiresAt()
+	if err != nil {
+		return nil, err
+	}
+	if expiration != nil {
+		status, err := c.GetStatusWithContext(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the latest block time: %w", err)
+		}
+		if !status.SyncInfo.LatestBlockTime.Before(*expiration) {
+			return nil, fmt.Errorf("fee allowance of %s to %s expired at %s: %w", granter, grantee, expiration, feegrant.ErrFeeLimitExpired)
+		}
+	}
+
+	return allowance, nil
+}
+
+// GrantFeeAllowance makes the client key pay the fees of the txs of grantee within the given allowance
+func (c *Client) GrantFeeAllowance(ctx context.Context, grantee string, allowance feegrant.FeeAllowanceI, opts ...TxOption) (*pv.RelayerTxResponse, error) {
+	granter, err := c.signerAddress(&feegrant.MsgGrantAllowance{}, newTxOptions(opts...))
+	if err != nil {
+		return nil, err
+	}
+	allowanceMsg, ok := allowance.(proto.Message)
+	if !ok {
+		return nil, fmt.Errorf("cannot proto marshal allowance of type %T", allowance)
+	}
+	allowanceAny, err := codectypes.NewAnyWithValue(allowanceMsg)
+	if err != nil {
+		return nil, err
+	}
+	msg := &feegrant.MsgGrantAllowance{
+		Granter:   granter,
+		Grantee:   grantee,
+		Allowance: allowanceAny,
+	}
+	return c.ReliablySendMsg(ctx, msg, nil, nil, append(opts, WithoutAuthz(), WithoutFeeGrant())...)
+}
+
+// GrantBasicFeeAllowance makes the client key pay the fees of the txs of grantee, up to spendLimit
+// if not empty and until expiration if not nil
+func (c *Client) GrantBasicFeeAllowance(ctx context.Context, grantee string, spendLimit sdk.Coins, expiration *time.Time, opts ...TxOption) (*pv.RelayerTxResponse, error) {
+	return c.GrantFeeAllowance(ctx, grantee, &feegrant.BasicAllowance{
+		SpendLimit: spendLimit,
+		Expiration: expiration,
+	}, opts...)
+}
+
+// RevokeFeeAllowance revokes the fee allowance the client key gives to grantee
+func (c *Client) RevokeFeeAllowance(ctx context.Context, grantee string, opts ...TxOption) (*pv.RelayerTxResponse, error) {
+	granter, err := c.signerAddress(&feegrant.MsgRevokeAllowance{}, newTxOptions(opts...))
+	if err != nil {
+		return nil, err
+	}
+	msg := &feegrant.MsgRevokeAllowance{
+		Granter: granter,
+		Grantee: grantee,
+	}
+	return c.ReliablySendMsg(ctx, msg, nil, nil, append(opts, WithoutAuthz(), WithoutFeeGrant())...)
+}
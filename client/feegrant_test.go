@@ -0,0 +1,141 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	btclctypes "github.com/Lorenzo-Protocol/lorenzo/v3/x/btclightclient/types"
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/libs/bytes"
+	rpcclient "github.com/cometbft/cometbft/rpc/client"
+	coretypes "github.com/cometbft/cometbft/rpc/core/types"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	"github.com/cosmos/cosmos-sdk/crypto/hd"
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	"github.com/cosmos/cosmos-sdk/x/feegrant"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Lorenzo-Protocol/lorenzo-sdk/v3/query"
+)
+
+func TestFeeGranter(t *testing.T) {
+	c := newOfflineTestClient(t)
+	c.provider.PCfg.Key = "relayer"
+	_, _, err := c.provider.Keybase.NewMnemonic("relayer", keyring.English, sdk.FullFundraiserPath, keyring.DefaultBIP39Passphrase, hd.Secp256k1)
+	require.NoError(t, err)
+	signer, err := c.GetAddr()
+	require.NoError(t, err)
+
+	granterAddr := sdk.AccAddress("treasury_address____")
+	granter, err := bech32.ConvertAndEncode("lrz", granterAddr)
+	require.NoError(t, err)
+	c.SetFeeGranter(granter)
+
+	buildTx := func(opts ...TxOption) sdk.FeeTx {
+		txf, err := c.prepareFactory(0, 0, newTxOptions(opts...))
+		require.NoError(t, err)
+		txb, err := txf.WithGas(100000).BuildUnsignedTx(&btclctypes.MsgInsertHeaders{Signer: signer})
+		require.NoError(t, err)
+		return txb.GetTx()
+	}
+	require.Equal(t, granterAddr, buildTx().FeeGranter())
+	require.Empty(t, buildTx(WithoutFeeGrant()).FeeGranter())
+
+	// txs failing because of the allowance are explained
+	var txErr error = &TxError{
+		Codespace: feegrant.ErrFeeLimitExceeded.Codespace(),
+		Code:      feegrant.ErrFeeLimitExceeded.ABCICode(),
+		RawLog:    "fee limit exceeded",
+	}
+	require.True(t, isFeeGrantError(txErr))
+	wrapped := c.wrapFeeGrantError(txErr, TxOptions{})
+	require.ErrorIs(t, wrapped, feegrant.ErrFeeLimitExceeded)
+	require.Contains(t, wrapped.Error(), "is exhausted")
+	require.Equal(t, txErr, c.wrapFeeGrantError(txErr, newTxOptions(WithoutFeeGrant())))
+}
+
+// fakeAllowanceNode is a node where every granter gives a basic fee allowance to every grantee,
+// recording the granters and grantees it is queried for
+type fakeAllowanceNode struct {
+	rpcclient.Client
+	granters []string
+	grantees []string
+}
+
+func (f *fakeAllowanceNode) ABCIQueryWithOptions(_ context.Context, path string, data bytes.HexBytes, _ rpcclient.ABCIQueryOptions) (*coretypes.ResultABCIQuery, error) {
+	if path != "/cosmos.feegrant.v1beta1.Query/Allowance" {
+		return nil, fmt.Errorf("unexpected query %s", path)
+	}
+	var req feegrant.QueryAllowanceRequest
+	if err := req.Unmarshal(data); err != nil {
+		return nil, err
+	}
+	f.granters = append(f.granters, req.Granter)
+	f.grantees = append(f.grantees, req.Grantee)
+
+	allowance, err := codectypes.NewAnyWithValue(&feegrant.BasicAllowance{})
+	if err != nil {
+		return nil, err
+	}
+	bz, err := (&feegrant.QueryAllowanceResponse{Allowance: &feegrant.Grant{
+		Granter:   req.Granter,
+		Grantee:   req.Grantee,
+		Allowance: allowance,
+	}}).Marshal()
+	if err != nil {
+		return nil, err
+	}
+	return &coretypes.ResultABCIQuery{Response: abci.ResponseQuery{Value: bz, Height: 1}}, nil
+}
+
+func TestFeeGrantAllowance(t *testing.T) {
+	c := newOfflineTestClient(t)
+	addrs := map[string]string{}
+	for _, keyName := range []string{"btc", "staking"} {
+		_, err := c.CreateKey(keyName)
+		require.NoError(t, err)
+		addrs[keyName], err = c.keyAddress(keyName)
+		require.NoError(t, err)
+	}
+	require.NoError(t, c.SetActiveKey("staking"))
+	btcMsgType := sdk.MsgTypeURL(&btclctypes.MsgInsertHeaders{})
+	require.NoError(t, c.SetKeyRoute(btcMsgType, "btc"))
+
+	node := &fakeAllowanceNode{}
+	var err error
+	c.QueryClient, err = query.NewWithClient(node, time.Second)
+	require.NoError(t, err)
+
+	_, err = c.FeeGrantAllowance(context.Background(), btcMsgType)
+	require.ErrorContains(t, err, "no fee granter")
+	granter, err := bech32.ConvertAndEncode("lrz", []byte("treasury_address____"))
+	require.NoError(t, err)
+	c.SetFeeGranter(granter)
+	other, err := bech32.ConvertAndEncode("lrz", []byte("other_granter_______"))
+	require.NoError(t, err)
+
+	// the grantee is the key signing the msgs of the type, as for the txs paid by the allowance
+	for _, tc := range []struct {
+		msgTypeURL string
+		opts       []TxOption
+		granter    string
+		grantee    string
+	}{
+		{btcMsgType, nil, granter, addrs["btc"]},
+		{sdk.MsgTypeURL(&btclctypes.MsgUpdateParams{}), nil, granter, addrs["staking"]},
+		{"", nil, granter, addrs["staking"]},
+		{btcMsgType, []TxOption{WithSigner("staking")}, granter, addrs["staking"]},
+		{btcMsgType, []TxOption{WithFeeGranter(other)}, other, addrs["btc"]},
+	} {
+		node.granters, node.grantees = nil, nil
+		allowance, err := c.FeeGrantAllowance(context.Background(), tc.msgTypeURL, tc.opts...)
+		require.NoError(t, err)
+		require.IsType(t, &feegrant.BasicAllowance{}, allowance)
+		require.Equal(t, []string{tc.granter}, node.granters, tc.msgTypeURL)
+		require.Equal(t, []string{tc.grantee}, node.grantees, tc.msgTypeURL)
+	}
+}
@@ -0,0 +1,152 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	lorenzoparams "github.com/Lorenzo-Protocol/lorenzo/v3/app/params"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// SimulationResult is the outcome of simulating a tx against the latest state of the node
+type SimulationResult struct {
+	// GasUsed is the gas consumed by the simulation
+	GasUsed uint64
+	// GasLimit is the gas limit the tx would be sent with
+	GasLimit uint64
+	// Fee is the fee the tx would be sent with
+	Fee sdk.Coins
+	// Events are the events emitted by the tx, in order
+	Events []sdk.StringEvent
+	// Result holds the data, log and msg responses of the tx
+	Result *sdk.Result
+}
+
+// Simulate simulates a tx made of msgs, signed with the client key and the gas and fee settings
+// of the config overridden by opts, and returns the gas it uses and the fee it would pay.
+// A tx failing the simulation returns a TxError.
+func (c *Client) Simulate(ctx context.Context, msgs []sdk.Msg, opts ...TxOption) (*SimulationResult, error) {
+	if len(msgs) == 0 {
+		return nil, fmt.Errorf("empty message set provided")
+	}
+	options := newTxOptions(opts...)
+	seqs, err := c.signerFor(msgs, options)
+	if err != nil {
+		return nil, err
+	}
+	if msgs, err = c.wrapAuthz(seqs.keyName, msgs, options); err != nil {
+		return nil, err
+	}
+
+	// the simulation checks the sequence of the tx against the mempool state of the node
+	accountNumber, sequence, err := seqs.next()
+	if err != nil {
+		return nil, err
+	}
+
+	txf, err := c.prepareFactory(accountNumber, sequence, options)
+	if err != nil {
+		return nil, err
+	}
+	simRes, txf, err := c.simulateNext(ctx, txf, seqs.keyName, msgs)
+	if err != nil {
+		return nil, c.wrapFeeGrantError(err, options)
+	}
+
+	gasLimit := options.GasLimit
+	if gasLimit == 0 {
+		gasLimit, err = c.adjustGas(simRes.GasInfo.GasUsed, options)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// the fee is computed the same way as when building the tx for real
+	txb, err := txf.WithGas(gasLimit).BuildUnsignedTx(msgs...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SimulationResult{
+		GasUsed:  simRes.GasInfo.GasUsed,
+		GasLimit: gasLimit,
+		Fee:      txb.GetTx().GetFee(),
+		Events:   stringifyEvents(simRes.Result.Events),
+		Result:   simRes.Result,
+	}, nil
+}
+
+// EstimateGasPrices returns the lowest gas price the chain currently accepts for a tx, in the denom
+// of the gas prices of the config or in the native denom if there are none. It is the highest of
+// the minimum gas price of the node, the global minimum gas price and the base fee of the fee market,
+// and is empty if the chain accepts txs without fee.
+func (c *Client) EstimateGasPrices(ctx context.Context) (sdk.DecCoins, error) {
+	denom, err := c.feeDenom()
+	if err != nil {
+		return nil, err
+	}
+	price := sdk.ZeroDec()
+
+	nodeConfig, err := c.NodeConfigWithContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query the minimum gas price of the node: %w", err)
+	}
+	if nodeConfig.MinimumGasPrice != "" {
+		nodePrices, err := sdk.ParseDecCoins(nodeConfig.MinimumGasPrice)
+		if err != nil {
+			return nil, fmt.Errorf("invalid minimum gas price of the node %s: %w", nodeConfig.MinimumGasPrice, err)
+		}
+		price = sdk.MaxDec(price, nodePrices.AmountOf(denom))
+	}
+
+	feeMarketParams, err := c.FeeMarketParamsWithContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query the fee market parameters: %w", err)
+	}
+	if !feeMarketParams.Params.MinGasPrice.IsNil() {
+		price = sdk.MaxDec(price, feeMarketParams.Params.MinGasPrice)
+	}
+
+	if !feeMarketParams.Params.NoBaseFee {
+		baseFee, err := c.FeeMarketBaseFeeWithContext(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query the base fee: %w", err)
+		}
+		if baseFee.BaseFee != nil {
+			price = sdk.MaxDec(price, sdk.NewDecFromInt(*baseFee.BaseFee))
+		}
+	}
+
+	return sdk.NewDecCoins(sdk.NewDecCoinFromDec(denom, price)), nil
+}
+
+// EstimateFee returns the fee of a tx with the given gas limit at the gas price
+// the chain currently accepts, as returned by EstimateGasPrices
+func (c *Client) EstimateFee(ctx context.Context, gasLimit uint64) (sdk.Coins, error) {
+	gasPrices, err := c.EstimateGasPrices(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	gas := sdk.NewDec(int64(gasLimit))
+	fees := sdk.NewCoins()
+	for _, gasPrice := range gasPrices {
+		fees = fees.Add(sdk.NewCoin(gasPrice.Denom, gasPrice.Amount.Mul(gas).Ceil().RoundInt()))
+	}
+	return fees, nil
+}
+
+// feeDenom returns the denom of the gas prices of the config, or the native denom if there are none
+func (c *Client) feeDenom() (string, error) {
+	if c.cfg.GasPrices == "" {
+		return lorenzoparams.BaseDenom, nil
+	}
+	gasPrices, err := sdk.ParseDecCoins(c.cfg.GasPrices)
+	if err != nil {
+		return "", fmt.Errorf("invalid gas prices %s: %w", c.cfg.GasPrices, err)
+	}
+	if len(gasPrices) != 1 {
+		return "", fmt.Errorf("expected gas prices in a single denom, got %s", c.cfg.GasPrices)
+	}
+	return gasPrices[0].Denom, nil
+}
@@ -0,0 +1,131 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	sdkmath "cosmossdk.io/math"
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/libs/bytes"
+	rpcclient "github.com/cometbft/cometbft/rpc/client"
+	coretypes "github.com/cometbft/cometbft/rpc/core/types"
+	"github.com/cosmos/cosmos-sdk/client/grpc/node"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	feemarkettypes "github.com/evmos/ethermint/x/feemarket/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Lorenzo-Protocol/lorenzo-sdk/v3/config"
+	"github.com/Lorenzo-Protocol/lorenzo-sdk/v3/query"
+)
+
+func TestSimulate(t *testing.T) {
+	c, _, _ := newBroadcastTestClient(t, 5)
+	addr, err := c.GetAddr()
+	require.NoError(t, err)
+	msgs := []sdk.Msg{banktypes.NewMsgSend(sdk.MustAccAddressFromBech32(addr), sdk.MustAccAddressFromBech32(addr), sdk.NewCoins(sdk.NewInt64Coin("alrz", 1)))}
+
+	// the fake node simulates every tx with 100000 gas, which the config adjusts by 1.5
+	for _, tc := range []struct {
+		name     string
+		opts     []TxOption
+		gasLimit uint64
+		fee      sdk.Coins
+	}{
+		{"config", nil, 150000, sdk.NewCoins()},
+		{"gas limit", []TxOption{WithGasLimit(300000)}, 300000, sdk.NewCoins()},
+		{"gas adjustment", []TxOption{WithGasAdjustment(2)}, 200000, sdk.NewCoins()},
+		{"gas prices", []TxOption{WithGasPrices(sdk.NewDecCoins(sdk.NewDecCoinFromDec("alrz", sdk.NewDecWithPrec(15, 1))))}, 150000, sdk.NewCoins(sdk.NewInt64Coin("alrz", 225000))},
+		{"gas prices rounded up", []TxOption{WithGasLimit(3), WithGasPrices(sdk.NewDecCoins(sdk.NewDecCoinFromDec("alrz", sdk.NewDecWithPrec(5, 1))))}, 3, sdk.NewCoins(sdk.NewInt64Coin("alrz", 2))},
+		{"fees", []TxOption{WithFees(sdk.NewCoins(sdk.NewInt64Coin("alrz", 42)))}, 150000, sdk.NewCoins(sdk.NewInt64Coin("alrz", 42))},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := c.Simulate(context.Background(), msgs, tc.opts...)
+			require.NoError(t, err)
+			require.Equal(t, uint64(100000), res.GasUsed)
+			require.Equal(t, tc.gasLimit, res.GasLimit)
+			require.Equal(t, tc.fee.String(), res.Fee.String())
+		})
+	}
+
+	_, err = c.Simulate(context.Background(), nil)
+	require.Error(t, err)
+}
+
+// fakeFeeNode serves the minimum gas price of the node and the fee market parameters
+type fakeFeeNode struct {
+	rpcclient.Client
+	minGasPrice string
+	params      feemarkettypes.Params
+	baseFee     *sdkmath.Int
+}
+
+func (f *fakeFeeNode) ABCIQueryWithOptions(_ context.Context, path string, _ bytes.HexBytes, _ rpcclient.ABCIQueryOptions) (*coretypes.ResultABCIQuery, error) {
+	var (
+		bz  []byte
+		err error
+	)
+	switch path {
+	case "/cosmos.base.node.v1beta1.Service/Config":
+		bz, err = (&node.ConfigResponse{MinimumGasPrice: f.minGasPrice}).Marshal()
+	case "/ethermint.feemarket.v1.Query/Params":
+		bz, err = (&feemarkettypes.QueryParamsResponse{Params: f.params}).Marshal()
+	case "/ethermint.feemarket.v1.Query/BaseFee":
+		bz, err = (&feemarkettypes.QueryBaseFeeResponse{BaseFee: f.baseFee}).Marshal()
+	default:
+		return nil, fmt.Errorf("unexpected query %s", path)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &coretypes.ResultABCIQuery{Response: abci.ResponseQuery{Value: bz, Height: 1}}, nil
+}
+
+func TestEstimateGasPrices(t *testing.T) {
+	params := func(noBaseFee bool, minGasPrice int64) feemarkettypes.Params {
+		return feemarkettypes.Params{
+			NoBaseFee:        noBaseFee,
+			BaseFee:          sdkmath.ZeroInt(),
+			MinGasPrice:      sdk.NewDec(minGasPrice),
+			MinGasMultiplier: sdk.ZeroDec(),
+		}
+	}
+	baseFee := sdkmath.NewInt(30)
+
+	for _, tc := range []struct {
+		name      string
+		gasPrices string
+		node      *fakeFeeNode
+		price     string
+		fee       string
+	}{
+		{"minimum gas price of the node", "", &fakeFeeNode{minGasPrice: "10alrz,1stake", params: params(true, 5)}, "10.000000000000000000alrz", "1000alrz"},
+		{"global minimum gas price", "", &fakeFeeNode{minGasPrice: "10alrz", params: params(true, 20)}, "20.000000000000000000alrz", "2000alrz"},
+		{"base fee", "", &fakeFeeNode{minGasPrice: "10alrz", params: params(false, 20), baseFee: &baseFee}, "30.000000000000000000alrz", "3000alrz"},
+		{"base fee disabled", "", &fakeFeeNode{params: params(true, 0), baseFee: &baseFee}, "", ""},
+		{"denom of the config", "0.5stake", &fakeFeeNode{minGasPrice: "10alrz,1.5stake", params: params(true, 0)}, "1.500000000000000000stake", "150stake"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			c := newOfflineTestClient(t)
+			c.cfg = &config.LorenzoConfig{GasPrices: tc.gasPrices}
+			var err error
+			c.QueryClient, err = query.NewWithClient(tc.node, time.Second)
+			require.NoError(t, err)
+
+			prices, err := c.EstimateGasPrices(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, tc.price, prices.String())
+			fee, err := c.EstimateFee(context.Background(), 100)
+			require.NoError(t, err)
+			require.Equal(t, tc.fee, fee.String())
+		})
+	}
+
+	// the gas prices of the config must be in a single denom
+	c := newOfflineTestClient(t)
+	c.cfg = &config.LorenzoConfig{GasPrices: "1alrz,1stake"}
+	_, err := c.EstimateGasPrices(context.Background())
+	require.Error(t, err)
+}
@@ -4,16 +4,105 @@ import (
 	"fmt"
 	"path"
 
+	"github.com/cosmos/cosmos-sdk/crypto/hd"
 	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/relayer/v2/relayer/chains/cosmos"
+	ethhd "github.com/evmos/ethermint/crypto/hd"
 	"github.com/juju/fslock"
 )
 
+const (
+	// KeyAlgoSecp256k1 is the signing algorithm of Cosmos SDK keys
+	KeyAlgoSecp256k1 = string(hd.Secp256k1Type)
+	// KeyAlgoEthSecp256k1 is the signing algorithm of Ethereum keys, the default one of Lorenzo
+	KeyAlgoEthSecp256k1 = string(ethhd.EthSecp256k1Type)
+
+	// ethereumCoinType is the BIP-44 coin type of Ethereum keys
+	ethereumCoinType = 60
+)
+
+// KeyInfo describes a key of the keyring
+type KeyInfo struct {
+	Name    string
+	Address string
+	PubKey  cryptotypes.PubKey
+	Algo    string
+	// Mnemonic is only set when the key is created, it is not stored in the keyring
+	Mnemonic string
+}
+
+// KeyOptions configures how CreateKey derives a key
+type KeyOptions struct {
+	// Mnemonic derives the key from the given mnemonic instead of a fresh one
+	Mnemonic string
+	// BIP39Passphrase is the passphrase of the mnemonic
+	BIP39Passphrase string
+	// HDPath is the derivation path of the key, the BIP-44 path of the coin type of Algo by default
+	HDPath string
+	// Algo is the signing algorithm of the key, KeyAlgoEthSecp256k1 by default
+	Algo string
+}
+
+type KeyOption func(*KeyOptions)
+
+// WithMnemonic derives the key from the given mnemonic
+func WithMnemonic(mnemonic string) KeyOption {
+	return func(options *KeyOptions) {
+		options.Mnemonic = mnemonic
+	}
+}
+
+// WithBIP39Passphrase derives the key from the mnemonic with the given passphrase
+func WithBIP39Passphrase(passphrase string) KeyOption {
+	return func(options *KeyOptions) {
+		options.BIP39Passphrase = passphrase
+	}
+}
+
+// WithHDPath derives the key at the given path, e.g. m/44'/60'/0'/0/1
+func WithHDPath(hdPath string) KeyOption {
+	return func(options *KeyOptions) {
+		options.HDPath = hdPath
+	}
+}
+
+// WithKeyAlgo creates a key with the given signing algorithm, KeyAlgoSecp256k1 or KeyAlgoEthSecp256k1
+func WithKeyAlgo(algo string) KeyOption {
+	return func(options *KeyOptions) {
+		options.Algo = algo
+	}
+}
+
+func newKeyOptions(opts ...KeyOption) KeyOptions {
+	options := KeyOptions{Algo: KeyAlgoEthSecp256k1}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return options
+}
+
+// signingAlgo returns the algorithm with the given name along with its default HD path
+func signingAlgo(name string) (keyring.SignatureAlgo, string, error) {
+	switch name {
+	case KeyAlgoSecp256k1:
+		return hd.Secp256k1, sdk.FullFundraiserPath, nil
+	case KeyAlgoEthSecp256k1:
+		return ethhd.EthSecp256k1, hd.CreateHDPath(ethereumCoinType, 0, 0).String(), nil
+	default:
+		return nil, "", fmt.Errorf("unsupported signing algorithm %s, expected %s or %s", name, KeyAlgoSecp256k1, KeyAlgoEthSecp256k1)
+	}
+}
+
 func (c *Client) GetAddr() (string, error) {
+	c.keyMu.RLock()
+	defer c.keyMu.RUnlock()
 	return c.provider.Address()
 }
 
 func (c *Client) MustGetAddr() string {
-	addr, err := c.provider.Address()
+	addr, err := c.GetAddr()
 	if err != nil {
 		panic(fmt.Errorf("failed to get signer: %v", err))
 	}
@@ -24,6 +113,179 @@ func (c *Client) GetKeyring() keyring.Keyring {
 	return c.provider.Keybase
 }
 
+// ActiveKey returns the name of the key signing the txs of the client
+func (c *Client) ActiveKey() string {
+	c.keyMu.RLock()
+	defer c.keyMu.RUnlock()
+	return c.provider.PCfg.Key
+}
+
+// SetActiveKey makes the key with the given name sign the txs of the client from now on,
+// except the ones with another signer, see SignerKeys. Txs already being sent keep their signer.
+func (c *Client) SetActiveKey(name string) error {
+	if _, err := c.GetKey(name); err != nil {
+		return err
+	}
+	if _, err := c.keySequences(name); err != nil {
+		return err
+	}
+
+	c.keyMu.Lock()
+	defer c.keyMu.Unlock()
+	c.provider.PCfg.Key = name
+	return nil
+}
+
+// CreateKey creates a key with the given name in the keyring, derived from a fresh mnemonic
+// unless opts give one. The returned info holds the mnemonic of the key.
+func (c *Client) CreateKey(name string, opts ...KeyOption) (*KeyInfo, error) {
+	options := newKeyOptions(opts...)
+	algo, hdPath, err := signingAlgo(options.Algo)
+	if err != nil {
+		return nil, err
+	}
+	if options.HDPath != "" {
+		hdPath = options.HDPath
+	}
+	if _, err := hd.NewParamsFromPath(hdPath); err != nil {
+		return nil, fmt.Errorf("invalid HD path %s: %w", hdPath, err)
+	}
+
+	mnemonic := options.Mnemonic
+	if mnemonic == "" {
+		if mnemonic, err = cosmos.CreateMnemonic(); err != nil {
+			return nil, err
+		}
+	}
+
+	var record *keyring.Record
+	if lockErr := c.accessKeyWithLock(func() {
+		record, err = c.provider.Keybase.NewAccount(name, mnemonic, options.BIP39Passphrase, hdPath, algo)
+	}); lockErr != nil {
+		return nil, lockErr
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create key %s: %w", name, err)
+	}
+
+	info, err := c.newKeyInfo(record)
+	if err != nil {
+		return nil, err
+	}
+	info.Mnemonic = mnemonic
+	return info, nil
+}
+
+// ImportKeyArmor imports under the given name a private key armored and encrypted with passphrase,
+// as exported by ExportKeyArmor or `lorenzod keys export`
+func (c *Client) ImportKeyArmor(name, armor, passphrase string) (*KeyInfo, error) {
+	var err error
+	if lockErr := c.accessKeyWithLock(func() {
+		err = c.provider.Keybase.ImportPrivKey(name, armor, passphrase)
+	}); lockErr != nil {
+		return nil, lockErr
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to import key %s: %w", name, err)
+	}
+	return c.GetKey(name)
+}
+
+// ExportKeyArmor exports the private key with the given name, armored and encrypted with passphrase
+func (c *Client) ExportKeyArmor(name, passphrase string) (string, error) {
+	var (
+		armor string
+		err   error
+	)
+	if lockErr := c.accessKeyWithLock(func() {
+		armor, err = c.provider.Keybase.ExportPrivKeyArmor(name, passphrase)
+	}); lockErr != nil {
+		return "", lockErr
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to export key %s: %w", name, err)
+	}
+	return armor, nil
+}
+
+// GetKey returns the key with the given name
+func (c *Client) GetKey(name string) (*KeyInfo, error) {
+	var (
+		record *keyring.Record
+		err    error
+	)
+	if lockErr := c.accessKeyWithLock(func() {
+		record, err = c.provider.Keybase.Key(name)
+	}); lockErr != nil {
+		return nil, lockErr
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get key %s: %w", name, err)
+	}
+	return c.newKeyInfo(record)
+}
+
+// ListKeys returns all the keys of the keyring
+func (c *Client) ListKeys() ([]*KeyInfo, error) {
+	var (
+		records []*keyring.Record
+		err     error
+	)
+	if lockErr := c.accessKeyWithLock(func() {
+		records, err = c.provider.Keybase.List()
+	}); lockErr != nil {
+		return nil, lockErr
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	infos := make([]*KeyInfo, 0, len(records))
+	for _, record := range records {
+		info, err := c.newKeyInfo(record)
+		if err != nil {
+			return nil, err
+		}
+		infos = append(infos, info)
+	}
+	return infos, nil
+}
+
+// DeleteKey deletes the key with the given name from the keyring, unless it is the active key
+func (c *Client) DeleteKey(name string) error {
+	if name == c.ActiveKey() {
+		return fmt.Errorf("cannot delete the active key %s", name)
+	}
+
+	var err error
+	if lockErr := c.accessKeyWithLock(func() {
+		err = c.provider.Keybase.Delete(name)
+	}); lockErr != nil {
+		return lockErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to delete key %s: %w", name, err)
+	}
+	return nil
+}
+
+func (c *Client) newKeyInfo(record *keyring.Record) (*KeyInfo, error) {
+	pubKey, err := record.GetPubKey()
+	if err != nil {
+		return nil, err
+	}
+	address, err := c.provider.EncodeBech32AccAddr(sdk.AccAddress(pubKey.Address()))
+	if err != nil {
+		return nil, err
+	}
+	return &KeyInfo{
+		Name:    record.Name,
+		Address: address,
+		PubKey:  pubKey,
+		Algo:    pubKey.Type(),
+	}, nil
+}
+
 // accessKeyWithLock triggers a function that access key ring while acquiring
 // the file system lock, in order to remain thread-safe when multiple concurrent
 // relayers are running on the same machine and accessing the same keyring
@@ -0,0 +1,66 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testMnemonic = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+
+func TestKeys(t *testing.T) {
+	c := newOfflineTestClient(t)
+
+	// keys are eth_secp256k1 keys on the Ethereum HD path by default
+	relayer, err := c.CreateKey("relayer", WithMnemonic(testMnemonic))
+	require.NoError(t, err)
+	require.Equal(t, KeyAlgoEthSecp256k1, relayer.Algo)
+	require.Equal(t, testMnemonic, relayer.Mnemonic)
+
+	cosmosKey, err := c.CreateKey("cosmos", WithMnemonic(testMnemonic), WithKeyAlgo(KeyAlgoSecp256k1))
+	require.NoError(t, err)
+	require.Equal(t, KeyAlgoSecp256k1, cosmosKey.Algo)
+	require.NotEqual(t, relayer.Address, cosmosKey.Address)
+
+	otherIndex, err := c.CreateKey("relayer-1", WithMnemonic(testMnemonic), WithHDPath("m/44'/60'/0'/0/1"))
+	require.NoError(t, err)
+	require.NotEqual(t, relayer.Address, otherIndex.Address)
+
+	fresh, err := c.CreateKey("fresh")
+	require.NoError(t, err)
+	require.NotEmpty(t, fresh.Mnemonic)
+
+	_, err = c.CreateKey("invalid", WithKeyAlgo("sr25519"))
+	require.Error(t, err)
+
+	keys, err := c.ListKeys()
+	require.NoError(t, err)
+	require.Len(t, keys, 4)
+
+	// an exported key is imported with the same address
+	armor, err := c.ExportKeyArmor("relayer", "passphrase")
+	require.NoError(t, err)
+	require.NoError(t, c.DeleteKey("relayer"))
+	_, err = c.GetKey("relayer")
+	require.Error(t, err)
+	imported, err := c.ImportKeyArmor("relayer", armor, "passphrase")
+	require.NoError(t, err)
+	require.Equal(t, relayer.Address, imported.Address)
+	require.Equal(t, KeyAlgoEthSecp256k1, imported.Algo)
+
+	// the active key signs the txs and cannot be deleted
+	require.NoError(t, c.SetActiveKey("relayer"))
+	require.Equal(t, "relayer", c.ActiveKey())
+	require.Contains(t, c.SignerKeys(), "relayer")
+	addr, err := c.GetAddr()
+	require.NoError(t, err)
+	require.Equal(t, relayer.Address, addr)
+	require.Error(t, c.DeleteKey("relayer"))
+
+	require.NoError(t, c.SetActiveKey("cosmos"))
+	addr, err = c.GetAddr()
+	require.NoError(t, err)
+	require.Equal(t, cosmosKey.Address, addr)
+	require.Error(t, c.SetActiveKey("unknown"))
+	require.Equal(t, "cosmos", c.ActiveKey())
+}
@@ -0,0 +1,239 @@
+package client
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/cosmos/cosmos-sdk/client/tx"
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	kmultisig "github.com/cosmos/cosmos-sdk/crypto/keys/multisig"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	"github.com/cosmos/cosmos-sdk/crypto/types/multisig"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
+	authsigning "github.com/cosmos/cosmos-sdk/x/auth/signing"
+)
+
+// Multisig members sign in amino JSON mode, since the bytes signed in direct mode
+// depend on the public key of the signer, which is the one of the multisig in the final tx.
+// The flow of a multisig tx is:
+//
+//  1. build the tx with BuildUnsignedTx and pass it around with WriteTxFile
+//  2. each member signs it with SignMultisigPartial and passes its signature around with WritePartialSignatures
+//  3. once enough members signed, CombineMultisig adds the multisig signature to the tx
+//  4. the tx is broadcast with BroadcastTxBytes
+
+// NewMultisigPubKey returns the public key of a multisig account whose members are pubKeys,
+// requiring threshold signatures. If sortKeys is set, the members are sorted by address
+// as `lorenzod keys add --multisig` does by default, making the key independent of their order.
+func NewMultisigPubKey(threshold int, pubKeys []cryptotypes.PubKey, sortKeys bool) (*kmultisig.LegacyAminoPubKey, error) {
+	if threshold <= 0 || threshold > len(pubKeys) {
+		return nil, fmt.Errorf("threshold must be between 1 and the %d members, got %d", len(pubKeys), threshold)
+	}
+
+	members := make([]cryptotypes.PubKey, len(pubKeys))
+	copy(members, pubKeys)
+	if sortKeys {
+		sort.Slice(members, func(i, j int) bool {
+			return bytes.Compare(members[i].Address(), members[j].Address()) < 0
+		})
+	}
+
+	return kmultisig.NewLegacyAminoPubKey(threshold, members), nil
+}
+
+// MultisigPubKeyFromKeys is like NewMultisigPubKey but with the members being keys of the keyring
+func (c *Client) MultisigPubKeyFromKeys(threshold int, keyNames []string, sortKeys bool) (*kmultisig.LegacyAminoPubKey, error) {
+	pubKeys := make([]cryptotypes.PubKey, 0, len(keyNames))
+	for _, keyName := range keyNames {
+		record, err := c.provider.Keybase.Key(keyName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get key %s: %w", keyName, err)
+		}
+		pubKey, err := record.GetPubKey()
+		if err != nil {
+			return nil, err
+		}
+		pubKeys = append(pubKeys, pubKey)
+	}
+	return NewMultisigPubKey(threshold, pubKeys, sortKeys)
+}
+
+// SaveMultisig stores the public key of a multisig account in the keyring under the given name
+func (c *Client) SaveMultisig(name string, pubKey *kmultisig.LegacyAminoPubKey) (*keyring.Record, error) {
+	var (
+		record *keyring.Record
+		err    error
+	)
+	if lockErr := c.accessKeyWithLock(func() {
+		record, err = c.provider.Keybase.SaveMultisig(name, pubKey)
+	}); lockErr != nil {
+		return nil, lockErr
+	}
+	return record, err
+}
+
+// SignMultisigPartial signs unsignedTx with the given key of the keyring, on behalf of the multisig
+// account with the given address, account number and sequence, without querying the node.
+// It returns the partial signature of the member, to be combined by CombineMultisig.
+func (c *Client) SignMultisigPartial(keyName string, multisigAddr sdk.AccAddress, unsignedTx sdk.Tx, accountNumber, sequence uint64) (signing.SignatureV2, error) {
+	txb, err := c.wrapTx(unsignedTx)
+	if err != nil {
+		return signing.SignatureV2{}, err
+	}
+	if !isTxSigner(multisigAddr, txb.GetTx().GetSigners()) {
+		return signing.SignatureV2{}, fmt.Errorf("multisig %s is not a signer of the tx", multisigAddr)
+	}
+
+	done := c.provider.SetSDKContext()
+	defer done()
+
+	txf := c.provider.TxFactory().
+		WithAccountNumber(accountNumber).
+		WithSequence(sequence).
+		WithSignMode(signing.SignMode_SIGN_MODE_LEGACY_AMINO_JSON)
+	if err := tx.Sign(txf, keyName, txb, true); err != nil {
+		return signing.SignatureV2{}, err
+	}
+
+	sigs, err := txb.GetTx().GetSignaturesV2()
+	if err != nil {
+		return signing.SignatureV2{}, err
+	}
+	return sigs[0], nil
+}
+
+// CombineMultisig returns unsignedTx signed by the multisig account with the given public key,
+// account number and sequence, out of the partial signatures of its members. Each partial signature
+// is verified, and there must be at least as many of them as the threshold of the multisig.
+// The signatures unsignedTx already has from its other signers are kept.
+func (c *Client) CombineMultisig(unsignedTx sdk.Tx, pubKey *kmultisig.LegacyAminoPubKey, accountNumber, sequence uint64, partials []signing.SignatureV2) (authsigning.Tx, error) {
+	txb, err := c.wrapTx(unsignedTx)
+	if err != nil {
+		return nil, err
+	}
+
+	done := c.provider.SetSDKContext()
+	defer done()
+
+	multisigAddr := sdk.AccAddress(pubKey.Address())
+	if !isTxSigner(multisigAddr, txb.GetTx().GetSigners()) {
+		return nil, fmt.Errorf("multisig %s is not a signer of the tx", multisigAddr)
+	}
+
+	signerData := authsigning.SignerData{
+		Address:       multisigAddr.String(),
+		ChainID:       c.provider.PCfg.ChainID,
+		AccountNumber: accountNumber,
+		Sequence:      sequence,
+		PubKey:        pubKey,
+	}
+	members := pubKey.GetPubKeys()
+	multisigData := multisig.NewMultisig(len(members))
+	signed := map[string]bool{}
+	for _, partial := range partials {
+		member := sdk.AccAddress(partial.PubKey.Address()).String()
+		if err := authsigning.VerifySignature(partial.PubKey, signerData, partial.Data, c.provider.Cdc.TxConfig.SignModeHandler(), txb.GetTx()); err != nil {
+			return nil, fmt.Errorf("invalid partial signature of %s: %w", member, err)
+		}
+		if err := multisig.AddSignatureV2(multisigData, partial, members); err != nil {
+			return nil, fmt.Errorf("failed to add the partial signature of %s: %w", member, err)
+		}
+		signed[member] = true
+	}
+	if threshold := int(pubKey.Threshold); len(signed) < threshold {
+		return nil, fmt.Errorf("multisig %s requires %d signatures, got %d", multisigAddr, threshold, len(signed))
+	}
+
+	// keep the signatures of the other signers, ordered as the signers of the tx
+	sigs, err := txb.GetTx().GetSignaturesV2()
+	if err != nil {
+		return nil, err
+	}
+	signatures := map[string]signing.SignatureV2{}
+	for _, sig := range sigs {
+		signatures[sdk.AccAddress(sig.PubKey.Address()).String()] = sig
+	}
+	signatures[multisigAddr.String()] = signing.SignatureV2{
+		PubKey:   pubKey,
+		Data:     multisigData,
+		Sequence: sequence,
+	}
+	sigs = make([]signing.SignatureV2, 0, len(signatures))
+	for _, signer := range txb.GetTx().GetSigners() {
+		if sig, ok := signatures[signer.String()]; ok {
+			sigs = append(sigs, sig)
+		}
+	}
+
+	if err := txb.SetSignatures(sigs...); err != nil {
+		return nil, err
+	}
+	return txb.GetTx(), nil
+}
+
+// MarshalPartialSignatures encodes partial signatures as JSON, in the format
+// written by `lorenzod tx sign --multisig` and read by `lorenzod tx multisign`
+func (c *Client) MarshalPartialSignatures(sigs ...signing.SignatureV2) ([]byte, error) {
+	return c.provider.Cdc.TxConfig.MarshalSignatureJSON(sigs)
+}
+
+// UnmarshalPartialSignatures decodes partial signatures encoded by MarshalPartialSignatures
+func (c *Client) UnmarshalPartialSignatures(bz []byte) ([]signing.SignatureV2, error) {
+	return c.provider.Cdc.TxConfig.UnmarshalSignatureJSON(bz)
+}
+
+// WritePartialSignatures writes partial signatures to the file at path, see MarshalPartialSignatures
+func (c *Client) WritePartialSignatures(path string, sigs ...signing.SignatureV2) error {
+	bz, err := c.MarshalPartialSignatures(sigs...)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, bz, 0o644)
+}
+
+// ReadPartialSignatures reads the partial signatures of the files at paths
+func (c *Client) ReadPartialSignatures(paths ...string) ([]signing.SignatureV2, error) {
+	var sigs []signing.SignatureV2
+	for _, path := range paths {
+		bz, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		fileSigs, err := c.UnmarshalPartialSignatures(bz)
+		if err != nil {
+			return nil, fmt.Errorf("invalid partial signatures in %s: %w", path, err)
+		}
+		sigs = append(sigs, fileSigs...)
+	}
+	return sigs, nil
+}
+
+// WriteTxFile writes sdkTx to the file at path, see EncodeTxJSON
+func (c *Client) WriteTxFile(path string, sdkTx sdk.Tx) error {
+	bz, err := c.EncodeTxJSON(sdkTx)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, bz, 0o644)
+}
+
+// ReadTxFile reads the tx of the file at path, see DecodeTxJSON
+func (c *Client) ReadTxFile(path string) (authsigning.Tx, error) {
+	bz, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return c.DecodeTxJSON(bz)
+}
+
+func isTxSigner(addr sdk.AccAddress, signers []sdk.AccAddress) bool {
+	for _, signer := range signers {
+		if signer.Equals(addr) {
+			return true
+		}
+	}
+	return false
+}
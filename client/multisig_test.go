@@ -0,0 +1,202 @@
+package client
+
+import (
+	"path/filepath"
+	"testing"
+
+	lorenzo "github.com/Lorenzo-Protocol/lorenzo/v3/app"
+	"github.com/cosmos/cosmos-sdk/crypto/hd"
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
+	authsigning "github.com/cosmos/cosmos-sdk/x/auth/signing"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/cosmos/relayer/v2/relayer/chains/cosmos"
+	ethhd "github.com/evmos/ethermint/crypto/hd"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func newOfflineTestClient(t *testing.T) *Client {
+	encCfg := lorenzo.MakeEncodingConfig()
+	return &Client{
+		logger:    zap.NewNop(),
+		signers:   map[string]*sequenceManager{},
+		keyRoutes: map[string]string{},
+		provider: &cosmos.CosmosProvider{
+			PCfg: cosmos.CosmosProviderConfig{
+				ChainID:       "lorenzo_83291-1",
+				AccountPrefix: "lrz",
+				KeyDirectory:  t.TempDir(),
+			},
+			Keybase: keyring.NewInMemory(encCfg.Codec, ethhd.EthSecp256k1Option()),
+			Cdc: cosmos.Codec{
+				InterfaceRegistry: encCfg.InterfaceRegistry,
+				Marshaler:         encCfg.Codec,
+				TxConfig:          encCfg.TxConfig,
+				Amino:             encCfg.Amino,
+			},
+		},
+	}
+}
+
+func TestMultisig(t *testing.T) {
+	c := newOfflineTestClient(t)
+
+	members := []string{"alice", "bob", "carol"}
+	for _, member := range members {
+		_, _, err := c.provider.Keybase.NewMnemonic(member, keyring.English, sdk.FullFundraiserPath, keyring.DefaultBIP39Passphrase, hd.Secp256k1)
+		require.NoError(t, err)
+	}
+
+	pubKey, err := c.MultisigPubKeyFromKeys(2, members, true)
+	require.NoError(t, err)
+	_, err = c.SaveMultisig("admin", pubKey)
+	require.NoError(t, err)
+	multisigAddr := sdk.AccAddress(pubKey.Address())
+
+	msg := banktypes.NewMsgSend(multisigAddr, multisigAddr, sdk.NewCoins(sdk.NewInt64Coin("alrz", 1)))
+	unsignedTx, err := c.BuildUnsignedTx([]sdk.Msg{msg}, OfflineTxParams{
+		AccountNumber: 7,
+		Sequence:      3,
+		GasLimit:      200000,
+		Fees:          sdk.NewCoins(sdk.NewInt64Coin("alrz", 1000)),
+		Memo:          "multisig",
+	})
+	require.NoError(t, err)
+
+	// the unsigned tx and the partial signatures are exchanged through files
+	dir := t.TempDir()
+	txFile := filepath.Join(dir, "tx.json")
+	require.NoError(t, c.WriteTxFile(txFile, unsignedTx))
+
+	var sigFiles []string
+	for _, member := range members[:2] {
+		memberTx, err := c.ReadTxFile(txFile)
+		require.NoError(t, err)
+		sig, err := c.SignMultisigPartial(member, multisigAddr, memberTx, 7, 3)
+		require.NoError(t, err)
+
+		sigFile := filepath.Join(dir, member+".json")
+		require.NoError(t, c.WritePartialSignatures(sigFile, sig))
+		sigFiles = append(sigFiles, sigFile)
+	}
+
+	partials, err := c.ReadPartialSignatures(sigFiles...)
+	require.NoError(t, err)
+	require.Len(t, partials, 2)
+
+	// a single partial signature does not reach the threshold
+	_, err = c.CombineMultisig(unsignedTx, pubKey, 7, 3, partials[:1])
+	require.Error(t, err)
+
+	// partial signatures are only valid for the account number and sequence they were made with
+	_, err = c.CombineMultisig(unsignedTx, pubKey, 7, 4, partials)
+	require.Error(t, err)
+
+	signedTx, err := c.CombineMultisig(unsignedTx, pubKey, 7, 3, partials)
+	require.NoError(t, err)
+	sigs, err := signedTx.GetSignaturesV2()
+	require.NoError(t, err)
+	require.Len(t, sigs, 1)
+	require.True(t, sigs[0].PubKey.Equals(pubKey))
+	require.Len(t, sigs[0].Data.(*signing.MultiSignatureData).Signatures, 2)
+
+	txBytes, err := c.EncodeTx(signedTx)
+	require.NoError(t, err)
+	decoded, err := c.DecodeTx(txBytes)
+	require.NoError(t, err)
+	require.Equal(t, "multisig", decoded.GetMemo())
+}
+
+func TestMultisigWithOtherSigner(t *testing.T) {
+	c := newOfflineTestClient(t)
+
+	members := []string{"alice", "bob"}
+	for _, member := range members {
+		_, _, err := c.provider.Keybase.NewMnemonic(member, keyring.English, sdk.FullFundraiserPath, keyring.DefaultBIP39Passphrase, hd.Secp256k1)
+		require.NoError(t, err)
+	}
+	pubKey, err := c.MultisigPubKeyFromKeys(2, members, true)
+	require.NoError(t, err)
+	multisigAddr := sdk.AccAddress(pubKey.Address())
+
+	_, err = c.CreateKey("dave")
+	require.NoError(t, err)
+	record, err := c.provider.Keybase.Key("dave")
+	require.NoError(t, err)
+	daveAddr, err := record.GetAddress()
+	require.NoError(t, err)
+	davePubKey, err := record.GetPubKey()
+	require.NoError(t, err)
+
+	coins := sdk.NewCoins(sdk.NewInt64Coin("alrz", 1))
+	unsignedTx, err := c.BuildUnsignedTx([]sdk.Msg{
+		banktypes.NewMsgSend(multisigAddr, daveAddr, coins),
+		banktypes.NewMsgSend(daveAddr, multisigAddr, coins),
+	}, OfflineTxParams{
+		GasLimit: 200000,
+		Fees:     sdk.NewCoins(sdk.NewInt64Coin("alrz", 1000)),
+	})
+	require.NoError(t, err)
+
+	var partials []signing.SignatureV2
+	for _, member := range members {
+		sig, err := c.SignMultisigPartial(member, multisigAddr, unsignedTx, 7, 3)
+		require.NoError(t, err)
+		partials = append(partials, sig)
+	}
+
+	for _, tc := range []struct {
+		name           string
+		daveSignsFirst bool
+	}{
+		{"other signer signs before the multisig is combined", true},
+		{"other signer signs after the multisig is combined", false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var signedTx sdk.Tx = unsignedTx
+			if tc.daveSignsFirst {
+				signedTx, err = c.SignTxOffline("dave", signedTx, 9, 0, false)
+				require.NoError(t, err)
+			}
+			signedTx, err = c.CombineMultisig(signedTx, pubKey, 7, 3, partials)
+			require.NoError(t, err)
+			if !tc.daveSignsFirst {
+				signedTx, err = c.SignTxOffline("dave", signedTx, 9, 0, false)
+				require.NoError(t, err)
+			}
+
+			txBytes, err := c.EncodeTx(signedTx)
+			require.NoError(t, err)
+			decoded, err := c.DecodeTx(txBytes)
+			require.NoError(t, err)
+
+			// both signatures are kept, ordered as the signers of the tx, and verify
+			sigs, err := decoded.GetSignaturesV2()
+			require.NoError(t, err)
+			require.Len(t, sigs, 2)
+			handler := c.provider.Cdc.TxConfig.SignModeHandler()
+			for i, signer := range []struct {
+				addr          sdk.AccAddress
+				pubKey        cryptotypes.PubKey
+				accountNumber uint64
+				sequence      uint64
+			}{
+				{multisigAddr, pubKey, 7, 3},
+				{daveAddr, davePubKey, 9, 0},
+			} {
+				require.True(t, signer.pubKey.Equals(sigs[i].PubKey))
+				signerData := authsigning.SignerData{
+					Address:       signer.addr.String(),
+					ChainID:       c.provider.PCfg.ChainID,
+					AccountNumber: signer.accountNumber,
+					Sequence:      signer.sequence,
+					PubKey:        signer.pubKey,
+				}
+				require.NoError(t, authsigning.VerifySignature(signer.pubKey, signerData, sigs[i].Data, handler, decoded))
+			}
+		})
+	}
+}
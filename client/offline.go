@@ -0,0 +1,185 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	sdkclient "github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/tx"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
+	authsigning "github.com/cosmos/cosmos-sdk/x/auth/signing"
+)
+
+// OfflineTxParams are the parameters of a tx built without querying the node
+type OfflineTxParams struct {
+	AccountNumber uint64
+	Sequence      uint64
+	GasLimit      uint64
+	// Fees is the fee of the tx, nil computes it out of the gas prices of the config
+	Fees          sdk.Coins
+	Memo          string
+	TimeoutHeight uint64
+}
+
+// offlineFactory returns the factory of a tx with the given parameters
+func (c *Client) offlineFactory(params OfflineTxParams) tx.Factory {
+	txf := c.provider.TxFactory().
+		WithAccountNumber(params.AccountNumber).
+		WithSequence(params.Sequence).
+		WithGas(params.GasLimit).
+		WithMemo(params.Memo).
+		WithTimeoutHeight(params.TimeoutHeight)
+	if params.Fees != nil {
+		txf = txf.WithGasPrices("").WithFees(params.Fees.String())
+	}
+	return txf
+}
+
+// BuildUnsignedTx builds an unsigned tx out of msgs with the given parameters, without querying the node
+func (c *Client) BuildUnsignedTx(msgs []sdk.Msg, params OfflineTxParams) (authsigning.Tx, error) {
+	if len(msgs) == 0 {
+		return nil, fmt.Errorf("empty message set provided")
+	}
+
+	done := c.provider.SetSDKContext()
+	defer done()
+
+	txb, err := c.offlineFactory(params).BuildUnsignedTx(msgs...)
+	if err != nil {
+		return nil, err
+	}
+	return txb.GetTx(), nil
+}
+
+// SignTxOffline signs unsignedTx with the given key of the keyring, as the signer with the given account
+// number and sequence, without querying the node. Unless overwrite is set, the signature is appended
+// to the ones the tx already has. The signed tx is returned, unsignedTx is left untouched.
+// A tx with several signers is signed in amino-json mode, whose sign bytes do not cover the
+// signatures of the other signers, so that signatures made separately can be combined.
+func (c *Client) SignTxOffline(keyName string, unsignedTx sdk.Tx, accountNumber, sequence uint64, overwrite bool) (authsigning.Tx, error) {
+	txb, err := c.wrapTx(unsignedTx)
+	if err != nil {
+		return nil, err
+	}
+
+	done := c.provider.SetSDKContext()
+	defer done()
+
+	txf := c.provider.TxFactory().
+		WithAccountNumber(accountNumber).
+		WithSequence(sequence)
+	if len(txb.GetTx().GetSigners()) > 1 {
+		txf = txf.WithSignMode(signing.SignMode_SIGN_MODE_LEGACY_AMINO_JSON)
+	}
+	if err := tx.Sign(txf, keyName, txb, overwrite); err != nil {
+		return nil, err
+	}
+	return txb.GetTx(), nil
+}
+
+// CombineSignatures returns unsignedTx with the signatures of signedTxs, which are copies of it
+// signed by its different signers, ordered as the signers of the tx
+func (c *Client) CombineSignatures(unsignedTx sdk.Tx, signedTxs ...sdk.Tx) (authsigning.Tx, error) {
+	txb, err := c.wrapTx(unsignedTx)
+	if err != nil {
+		return nil, err
+	}
+
+	done := c.provider.SetSDKContext()
+	defer done()
+
+	signatures := map[string]signing.SignatureV2{}
+	for _, signedTx := range signedTxs {
+		sigTx, ok := signedTx.(authsigning.SigVerifiableTx)
+		if !ok {
+			return nil, fmt.Errorf("tx of type %T cannot hold signatures", signedTx)
+		}
+		sigs, err := sigTx.GetSignaturesV2()
+		if err != nil {
+			return nil, err
+		}
+		for _, sig := range sigs {
+			signatures[sdk.AccAddress(sig.PubKey.Address()).String()] = sig
+		}
+	}
+
+	signers := txb.GetTx().GetSigners()
+	sigs := make([]signing.SignatureV2, 0, len(signers))
+	for _, signer := range signers {
+		sig, ok := signatures[signer.String()]
+		if !ok {
+			return nil, fmt.Errorf("missing signature of signer %s", signer)
+		}
+		sigs = append(sigs, sig)
+	}
+
+	if err := txb.SetSignatures(sigs...); err != nil {
+		return nil, err
+	}
+	return txb.GetTx(), nil
+}
+
+// EncodeTxJSON encodes sdkTx as JSON, in the format read and written by `lorenzod tx sign`
+func (c *Client) EncodeTxJSON(sdkTx sdk.Tx) ([]byte, error) {
+	return c.provider.Cdc.TxConfig.TxJSONEncoder()(sdkTx)
+}
+
+// DecodeTxJSON decodes a tx encoded as JSON, e.g. by EncodeTxJSON or by `lorenzod tx sign`
+func (c *Client) DecodeTxJSON(txJSON []byte) (authsigning.Tx, error) {
+	decoded, err := c.provider.Cdc.TxConfig.TxJSONDecoder()(txJSON)
+	if err != nil {
+		return nil, err
+	}
+	return c.toSigningTx(decoded)
+}
+
+// EncodeTx encodes sdkTx in the binary format broadcast to the node
+func (c *Client) EncodeTx(sdkTx sdk.Tx) ([]byte, error) {
+	return c.provider.Cdc.TxConfig.TxEncoder()(sdkTx)
+}
+
+// DecodeTx decodes a tx in the binary format broadcast to the node
+func (c *Client) DecodeTx(txBytes []byte) (authsigning.Tx, error) {
+	decoded, err := c.provider.Cdc.TxConfig.TxDecoder()(txBytes)
+	if err != nil {
+		return nil, err
+	}
+	return c.toSigningTx(decoded)
+}
+
+// BroadcastTxBytes broadcasts a signed tx encoded by EncodeTx and returns its hash as soon as
+// the node accepted it. Use WaitForTx to wait for the tx to be included in a block.
+// A tx rejected by the node fails with a TxError.
+func (c *Client) BroadcastTxBytes(ctx context.Context, txBytes []byte) (string, error) {
+	res, err := c.RPCClient.BroadcastTxSync(ctx, txBytes)
+	if err != nil {
+		return "", err
+	}
+	if res.Code != 0 {
+		return "", newCheckTxError(res)
+	}
+	return res.Hash.String(), nil
+}
+
+// wrapTx returns a builder holding a copy of unsignedTx
+func (c *Client) wrapTx(unsignedTx sdk.Tx) (sdkclient.TxBuilder, error) {
+	// wrap a decoded copy, so that the builder does not modify unsignedTx
+	txBytes, err := c.EncodeTx(unsignedTx)
+	if err != nil {
+		return nil, err
+	}
+	decoded, err := c.provider.Cdc.TxConfig.TxDecoder()(txBytes)
+	if err != nil {
+		return nil, err
+	}
+	return c.provider.Cdc.TxConfig.WrapTxBuilder(decoded)
+}
+
+func (c *Client) toSigningTx(decoded sdk.Tx) (authsigning.Tx, error) {
+	signingTx, ok := decoded.(authsigning.Tx)
+	if !ok {
+		return nil, fmt.Errorf("unexpected tx of type %T", decoded)
+	}
+	return signingTx, nil
+}
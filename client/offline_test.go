@@ -0,0 +1,91 @@
+package client
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authsigning "github.com/cosmos/cosmos-sdk/x/auth/signing"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOfflineSignAndCombine(t *testing.T) {
+	c := newOfflineTestClient(t)
+
+	type signer struct {
+		name          string
+		accountNumber uint64
+		sequence      uint64
+	}
+	signers := []signer{{"alice", 7, 3}, {"bob", 9, 0}}
+	var addrs []sdk.AccAddress
+	for _, s := range signers {
+		_, err := c.CreateKey(s.name)
+		require.NoError(t, err)
+		record, err := c.provider.Keybase.Key(s.name)
+		require.NoError(t, err)
+		addr, err := record.GetAddress()
+		require.NoError(t, err)
+		addrs = append(addrs, addr)
+	}
+
+	coins := sdk.NewCoins(sdk.NewInt64Coin("alrz", 1))
+	msgs := []sdk.Msg{
+		banktypes.NewMsgSend(addrs[0], addrs[1], coins),
+		banktypes.NewMsgSend(addrs[1], addrs[0], coins),
+	}
+	unsignedTx, err := c.BuildUnsignedTx(msgs, OfflineTxParams{
+		GasLimit: 200000,
+		Fees:     sdk.NewCoins(sdk.NewInt64Coin("alrz", 1000)),
+	})
+	require.NoError(t, err)
+
+	// each signer signs its own copy of the tx, which leaves the unsigned tx untouched
+	var signedTxs []sdk.Tx
+	for _, s := range signers {
+		signedTx, err := c.SignTxOffline(s.name, unsignedTx, s.accountNumber, s.sequence, true)
+		require.NoError(t, err)
+		signedTxs = append(signedTxs, signedTx)
+	}
+	sigs, err := unsignedTx.GetSignaturesV2()
+	require.NoError(t, err)
+	require.Empty(t, sigs)
+
+	// the signatures are combined out of order and survive the encoding of the tx
+	combined, err := c.CombineSignatures(unsignedTx, signedTxs[1], signedTxs[0])
+	require.NoError(t, err)
+	txBytes, err := c.EncodeTx(combined)
+	require.NoError(t, err)
+	decoded, err := c.DecodeTx(txBytes)
+	require.NoError(t, err)
+
+	sigs, err = decoded.GetSignaturesV2()
+	require.NoError(t, err)
+	require.Len(t, sigs, len(signers))
+	handler := c.provider.Cdc.TxConfig.SignModeHandler()
+	for i, s := range signers {
+		record, err := c.provider.Keybase.Key(s.name)
+		require.NoError(t, err)
+		pubKey, err := record.GetPubKey()
+		require.NoError(t, err)
+		require.True(t, pubKey.Equals(sigs[i].PubKey))
+		require.Equal(t, s.sequence, sigs[i].Sequence)
+
+		signerData := authsigning.SignerData{
+			Address:       addrs[i].String(),
+			ChainID:       c.provider.PCfg.ChainID,
+			AccountNumber: s.accountNumber,
+			Sequence:      s.sequence,
+			PubKey:        pubKey,
+		}
+		require.NoError(t, authsigning.VerifySignature(pubKey, signerData, sigs[i].Data, handler, decoded))
+
+		// a signature does not verify with the account number of another signer
+		signerData.AccountNumber++
+		require.Error(t, authsigning.VerifySignature(pubKey, signerData, sigs[i].Data, handler, decoded))
+	}
+
+	// a signer missing from the signed copies cannot be combined
+	_, err = c.CombineSignatures(unsignedTx, signedTxs[0])
+	require.ErrorContains(t, err, "missing signature")
+}
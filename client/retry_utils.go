@@ -1,37 +1,147 @@
 package client
 
 import (
-	"strings"
+	"context"
 	"time"
 
 	"cosmossdk.io/errors"
 	"github.com/avast/retry-go/v4"
-)
+	"go.uber.org/zap"
 
-// Variables used for retries
-var (
-	rtyAttNum = uint(5)
-	rtyAtt    = retry.Attempts(rtyAttNum)
-	rtyDel    = retry.Delay(time.Millisecond * 400)
-	rtyErr    = retry.LastErrorOnly(true)
+	"github.com/Lorenzo-Protocol/lorenzo-sdk/v3/config"
 )
 
+// RetryPolicy defines how a Client retries submitting a tx.
+// The delay between two attempts grows exponentially from Delay,
+// plus a random jitter of up to MaxJitter, and is capped at MaxDelay.
+type RetryPolicy struct {
+	// Attempts is the maximum number of attempts, 0 retries until success
+	Attempts uint
+	// Delay is the delay before the first retry
+	Delay time.Duration
+	// MaxDelay caps the delay between two attempts, 0 means no cap
+	MaxDelay time.Duration
+	// MaxJitter is the maximum random delay added to each delay, 0 adds none
+	MaxJitter time.Duration
+	// LastErrorOnly returns only the error of the last attempt instead of all of them
+	LastErrorOnly bool
+	// IsRetryable decides whether an error is worth retrying, nil retries all errors
+	IsRetryable func(err error) bool
+}
+
+// DefaultRetryPolicy returns the retry policy used by a Client unless configured otherwise
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		Attempts:      5,
+		Delay:         time.Millisecond * 400,
+		MaxDelay:      time.Second * 30,
+		MaxJitter:     time.Millisecond * 100,
+		LastErrorOnly: true,
+	}
+}
+
+// retryPolicyFromConfig returns the default retry policy overridden by the retry settings of cfg
+func retryPolicyFromConfig(cfg *config.LorenzoConfig) RetryPolicy {
+	policy := DefaultRetryPolicy()
+	if cfg.RetryAttempts > 0 {
+		policy.Attempts = cfg.RetryAttempts
+	}
+	if cfg.RetryDelay > 0 {
+		policy.Delay = cfg.RetryDelay
+	}
+	if cfg.RetryMaxDelay > 0 {
+		policy.MaxDelay = cfg.RetryMaxDelay
+	}
+	if cfg.RetryMaxJitter > 0 {
+		policy.MaxJitter = cfg.RetryMaxJitter
+	}
+	return policy
+}
+
+// retryOptions returns the retry-go options applying the policy within ctx
+func (p RetryPolicy) retryOptions(ctx context.Context, logger *zap.Logger) []retry.Option {
+	// a random delay with no jitter is not supported by retry-go
+	delayType := retry.BackOffDelay
+	if p.MaxJitter > 0 {
+		delayType = retry.CombineDelay(retry.BackOffDelay, retry.RandomDelay)
+	}
+	opts := []retry.Option{
+		retry.Context(ctx),
+		retry.Attempts(p.Attempts),
+		retry.Delay(p.Delay),
+		retry.MaxDelay(p.MaxDelay),
+		retry.MaxJitter(p.MaxJitter),
+		retry.DelayType(delayType),
+		retry.LastErrorOnly(p.LastErrorOnly),
+		retry.OnRetry(func(n uint, err error) {
+			logger.Debug("retrying", zap.Uint("attempt", n+1), zap.Uint("max_attempts", p.Attempts), zap.Error(err))
+		}),
+	}
+	if p.IsRetryable != nil {
+		opts = append(opts, retry.RetryIf(p.IsRetryable))
+	}
+	return opts
+}
+
+// GetRetryPolicy returns the retry policy of the client
+func (c *Client) GetRetryPolicy() RetryPolicy {
+	c.retryMu.RLock()
+	defer c.retryMu.RUnlock()
+
+	return c.retryPolicy
+}
+
+// SetRetryPolicy replaces the retry policy of the client
+func (c *Client) SetRetryPolicy(policy RetryPolicy) {
+	c.retryMu.Lock()
+	defer c.retryMu.Unlock()
+
+	c.retryPolicy = policy
+}
+
+// updateRetryPolicy applies f to the retry policy of the client
+func (c *Client) updateRetryPolicy(f func(policy *RetryPolicy)) {
+	c.retryMu.Lock()
+	defer c.retryMu.Unlock()
+
+	f(&c.retryPolicy)
+}
+
 func (c *Client) SetRetryAttempts(retryNumber uint) {
-	rtyAttNum = retryNumber
-	rtyAtt = retry.Attempts(rtyAttNum)
+	c.updateRetryPolicy(func(policy *RetryPolicy) {
+		policy.Attempts = retryNumber
+	})
 }
 
 func (c *Client) SetRetryDelay(milliseconds int) {
-	rtyDel = retry.Delay(time.Millisecond * time.Duration(milliseconds))
+	c.updateRetryPolicy(func(policy *RetryPolicy) {
+		policy.Delay = time.Millisecond * time.Duration(milliseconds)
+	})
+}
+
+func (c *Client) SetRetryMaxDelay(maxDelay time.Duration) {
+	c.updateRetryPolicy(func(policy *RetryPolicy) {
+		policy.MaxDelay = maxDelay
+	})
 }
 
 func (c *Client) SetIsReturnLatestErrorOnly(isReturnLatestErrorOnly bool) {
-	rtyErr = retry.LastErrorOnly(isReturnLatestErrorOnly)
+	c.updateRetryPolicy(func(policy *RetryPolicy) {
+		policy.LastErrorOnly = isReturnLatestErrorOnly
+	})
+}
+
+// SetRetryClassifier sets the function deciding which errors are retried, nil retries all errors
+func (c *Client) SetRetryClassifier(isRetryable func(err error) bool) {
+	c.updateRetryPolicy(func(policy *RetryPolicy) {
+		policy.IsRetryable = isRetryable
+	})
 }
 
+// errorContained returns whether err matches any of the registered errors in errList
 func errorContained(err error, errList []*errors.Error) bool {
 	for _, e := range errList {
-		if strings.Contains(err.Error(), e.Error()) {
+		if errors.IsOf(err, e) {
 			return true
 		}
 	}
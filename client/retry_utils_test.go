@@ -0,0 +1,149 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/avast/retry-go/v4"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/Lorenzo-Protocol/lorenzo-sdk/v3/config"
+)
+
+func TestRetryPolicyFromConfig(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		cfg      config.LorenzoConfig
+		expected func(*RetryPolicy)
+	}{
+		{"defaults", config.LorenzoConfig{}, func(*RetryPolicy) {}},
+		{"attempts", config.LorenzoConfig{RetryAttempts: 9}, func(p *RetryPolicy) { p.Attempts = 9 }},
+		{"delays", config.LorenzoConfig{RetryDelay: time.Second, RetryMaxDelay: time.Minute, RetryMaxJitter: time.Millisecond}, func(p *RetryPolicy) {
+			p.Delay = time.Second
+			p.MaxDelay = time.Minute
+			p.MaxJitter = time.Millisecond
+		}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			expected := DefaultRetryPolicy()
+			tc.expected(&expected)
+			require.Equal(t, expected, retryPolicyFromConfig(&tc.cfg))
+		})
+	}
+}
+
+func TestRetryPolicy(t *testing.T) {
+	transient := errors.New("connection refused")
+	permanent := errors.New("invalid msg")
+	isTransient := func(err error) bool {
+		return strings.Contains(err.Error(), "connection refused")
+	}
+
+	for _, tc := range []struct {
+		name   string
+		policy RetryPolicy
+		errs   []error
+		calls  int
+		err    error
+		all    int
+	}{
+		{"succeeds after retries", RetryPolicy{Attempts: 5, LastErrorOnly: true}, []error{transient, transient}, 3, nil, 0},
+		{"attempts exhausted", RetryPolicy{Attempts: 2, LastErrorOnly: true}, []error{transient, permanent, transient}, 2, permanent, 0},
+		{"all errors returned", RetryPolicy{Attempts: 2}, []error{transient, permanent, transient}, 2, nil, 2},
+		{"error not retryable", RetryPolicy{Attempts: 5, LastErrorOnly: true, IsRetryable: isTransient}, []error{permanent, transient}, 1, permanent, 0},
+		{"retryable error", RetryPolicy{Attempts: 5, LastErrorOnly: true, IsRetryable: isTransient}, []error{transient, permanent}, 2, permanent, 0},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.policy.Delay = time.Millisecond
+			tc.policy.MaxDelay = 2 * time.Millisecond
+			tc.policy.MaxJitter = time.Millisecond
+
+			calls := 0
+			err := retry.Do(func() error {
+				calls++
+				if calls <= len(tc.errs) {
+					return tc.errs[calls-1]
+				}
+				return nil
+			}, tc.policy.retryOptions(context.Background(), zap.NewNop())...)
+
+			require.Equal(t, tc.calls, calls)
+			switch {
+			case tc.err != nil:
+				require.Equal(t, tc.err, err)
+			case tc.all > 0:
+				var all retry.Error
+				require.ErrorAs(t, err, &all)
+				require.Len(t, all, tc.all)
+			default:
+				require.NoError(t, err)
+			}
+		})
+	}
+
+	// the delays may have no jitter
+	calls := 0
+	err := retry.Do(func() error {
+		calls++
+		if calls == 1 {
+			return transient
+		}
+		return nil
+	}, RetryPolicy{Attempts: 5, Delay: time.Millisecond}.retryOptions(context.Background(), zap.NewNop())...)
+	require.NoError(t, err)
+	require.Equal(t, 2, calls)
+
+	// the retries stop with the context
+	ctx, cancel := context.WithCancel(context.Background())
+	calls = 0
+	err = retry.Do(func() error {
+		calls++
+		cancel()
+		return transient
+	}, RetryPolicy{Attempts: 5, Delay: time.Hour, LastErrorOnly: true}.retryOptions(ctx, zap.NewNop())...)
+	require.Error(t, err)
+	require.Equal(t, 1, calls)
+}
+
+func TestSetRetryClassifier(t *testing.T) {
+	c, node, seqs := newBroadcastTestClient(t, 5)
+	c.SetRetryPolicy(RetryPolicy{Attempts: 3, Delay: time.Millisecond, LastErrorOnly: true})
+	addr, err := c.GetAddr()
+	require.NoError(t, err)
+	msgs := []sdk.Msg{banktypes.NewMsgSend(sdk.MustAccAddressFromBech32(addr), sdk.MustAccAddressFromBech32(addr), sdk.NewCoins(sdk.NewInt64Coin("alrz", 1)))}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// broadcast errors are retried by default
+	node.broadcastErrs = []error{errors.New("connection refused"), errors.New("mempool is full")}
+	_, sequence, err := c.sendMsgs(ctx, seqs, msgs, nil, nil, TxOptions{GasLimit: 200000})
+	require.NoError(t, err)
+	require.Equal(t, uint64(5), sequence)
+	require.Equal(t, 3, node.broadcasts)
+
+	// the classifier of the client decides which ones are retried
+	c.SetRetryClassifier(func(err error) bool {
+		return strings.Contains(err.Error(), "connection refused")
+	})
+	node.broadcasts = 0
+	node.broadcastErrs = []error{errors.New("connection refused"), errors.New("mempool is full")}
+	_, _, err = c.sendMsgs(ctx, seqs, msgs, nil, nil, TxOptions{GasLimit: 200000})
+	require.ErrorContains(t, err, "mempool is full")
+	require.Equal(t, 2, node.broadcasts)
+	require.Len(t, node.mempool, 1)
+
+	// and the policy of the client is not shared with other clients
+	other := newOfflineTestClient(t)
+	other.retryPolicy = DefaultRetryPolicy()
+	require.Nil(t, other.GetRetryPolicy().IsRetryable)
+	require.NotNil(t, c.GetRetryPolicy().IsRetryable)
+	c.SetRetryAttempts(7)
+	require.Equal(t, uint(7), c.GetRetryPolicy().Attempts)
+	require.Equal(t, DefaultRetryPolicy().Attempts, other.GetRetryPolicy().Attempts)
+}
@@ -0,0 +1,249 @@
+package client
+
+import (
+	"context"
+	"sync"
+)
+
+// maxPendingTxs bounds the number of broadcast txs a sequenceManager keeps for rebroadcasting.
+// Past it, the manager resyncs from chain to forget the txs that were included.
+const maxPendingTxs = 1000
+
+// sequenceManager tracks the account number and sequence of a signing key locally,
+// so that txs can be signed one after the other without querying the account for each of them.
+// It keeps the txs broadcast with each sequence until they are known to be included,
+// in order to rebroadcast them if the node drops them and leaves a gap in the sequence.
+//
+// mu is only held to update the local state, never across an RPC call but the fetch of the
+// account. A tx reserves its sequence, is simulated and signed concurrently with the other
+// txs, and then waits for its turn, as the node only accepts txs in the order of their
+// sequence. A tx failing before it is accepted releases its sequence, which invalidates the
+// reservations of the txs signed after it: they are signed again with new sequences.
+type sequenceManager struct {
+	mu sync.Mutex
+	// turn is signalled whenever the next sequence to broadcast or the reservations change
+	turn *sync.Cond
+
+	// keyName is the name of the signing key in the keyring
+	keyName string
+	// stats counts the txs sent with the key
+	stats sendStats
+	// fetch returns the account number and sequence of the key from chain
+	fetch func() (accountNumber uint64, sequence uint64, err error)
+
+	// known is set once the sequence was fetched from chain, synced as long as no resync is needed
+	known         bool
+	synced        bool
+	accountNumber uint64
+	// sequence is the next sequence to reserve and broadcast the next sequence to broadcast,
+	// the sequences in between being reserved by txs that are not accepted yet
+	sequence  uint64
+	broadcast uint64
+	reserved  map[uint64]uint64
+	nextID    uint64
+	pending   map[uint64][]byte
+}
+
+// reservation is a sequence reserved by a tx. It is invalidated once the tx of an earlier
+// sequence fails or the sequence is reset.
+type reservation struct {
+	id            uint64
+	accountNumber uint64
+	sequence      uint64
+}
+
+func newSequenceManager(fetch func() (uint64, uint64, error)) *sequenceManager {
+	m := &sequenceManager{
+		fetch:    fetch,
+		reserved: map[uint64]uint64{},
+		pending:  map[uint64][]byte{},
+	}
+	m.turn = sync.NewCond(&m.mu)
+	return m
+}
+
+// next returns the account number and the sequence the node expects once the txs being
+// broadcast are accepted, which the simulations are run with, syncing them from chain first if needed
+func (m *sequenceManager) next() (uint64, uint64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := m.syncIfNeeded(); err != nil {
+		return 0, 0, err
+	}
+	return m.accountNumber, m.broadcast, nil
+}
+
+// reserve reserves the sequence of the next tx, syncing it from chain first if needed
+func (m *sequenceManager) reserve() (reservation, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := m.syncIfNeeded(); err != nil {
+		return reservation{}, err
+	}
+	m.nextID++
+	r := reservation{id: m.nextID, accountNumber: m.accountNumber, sequence: m.sequence}
+	m.reserved[r.sequence] = r.id
+	m.sequence++
+	return r, nil
+}
+
+// waitTurn waits until the txs of the sequences before the one of r are accepted by the node.
+// It returns false if r was invalidated meanwhile, in which case the tx must reserve another
+// sequence, and fails if ctx is done first.
+func (m *sequenceManager) waitTurn(ctx context.Context, r reservation) (bool, error) {
+	stop := context.AfterFunc(ctx, func() {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		m.turn.Broadcast()
+	})
+	defer stop()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for {
+		if m.reserved[r.sequence] != r.id {
+			return false, nil
+		}
+		if m.broadcast == r.sequence {
+			return true, nil
+		}
+		if err := ctx.Err(); err != nil {
+			return false, err
+		}
+		m.turn.Wait()
+	}
+}
+
+// commit records that txBytes were accepted in the mempool with the sequence of r,
+// which passes the turn to the next sequence
+func (m *sequenceManager) commit(r reservation, txBytes []byte) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.reserved, r.sequence)
+	m.pending[r.sequence] = txBytes
+	m.broadcast = r.sequence + 1
+	if len(m.pending) > maxPendingTxs {
+		m.synced = false
+	}
+	m.turn.Broadcast()
+}
+
+// release gives the sequence of r back after its tx failed before being accepted.
+// The txs reserved after it are signed with a sequence the node will not accept anymore,
+// so their reservations are invalidated and the sequence is handed out again from r on.
+func (m *sequenceManager) release(r reservation) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.reserved[r.sequence] != r.id {
+		return
+	}
+	for sequence := range m.reserved {
+		if sequence >= r.sequence {
+			delete(m.reserved, sequence)
+		}
+	}
+	m.sequence = r.sequence
+	m.turn.Broadcast()
+}
+
+// confirm forgets the tx with the given sequence, which was included in a block
+func (m *sequenceManager) confirm(sequence uint64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.pending, sequence)
+}
+
+// gap handles an account sequence mismatch where the node expects the given sequence
+// instead of the next one to broadcast. If the node is behind, it returns the txs it lost
+// in order of sequence, so that they can be rebroadcast; if some of them are not known
+// anymore, or if the node is ahead because another signer used the key, the sequence
+// is reset to the expected one.
+func (m *sequenceManager) gap(expected uint64) [][]byte {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.forgetBefore(expected)
+	if expected >= m.broadcast {
+		m.resetTo(expected)
+		return nil
+	}
+
+	txs := make([][]byte, 0, m.broadcast-expected)
+	for sequence := expected; sequence < m.broadcast; sequence++ {
+		txBytes, ok := m.pending[sequence]
+		if !ok {
+			m.resetTo(expected)
+			return nil
+		}
+		txs = append(txs, txBytes)
+	}
+	return txs
+}
+
+// reset makes sequence the next sequence to reserve and to broadcast
+func (m *sequenceManager) reset(sequence uint64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.resetTo(sequence)
+}
+
+// resetTo makes sequence the next sequence to reserve and to broadcast, forgets the txs
+// sent from it on and invalidates all reservations. The caller must hold mu.
+func (m *sequenceManager) resetTo(sequence uint64) {
+	for s := range m.pending {
+		if s >= sequence {
+			delete(m.pending, s)
+		}
+	}
+	for s := range m.reserved {
+		delete(m.reserved, s)
+	}
+	m.sequence = sequence
+	m.broadcast = sequence
+	m.turn.Broadcast()
+}
+
+// syncIfNeeded syncs the account from chain if it was never fetched, or if a resync is
+// needed and no tx holds a reservation. The caller must hold mu.
+func (m *sequenceManager) syncIfNeeded() error {
+	if !m.known || (!m.synced && len(m.reserved) == 0) {
+		return m.sync()
+	}
+	return nil
+}
+
+// sync fetches the account number and sequence from chain and forgets the txs
+// that were included. The local sequence is kept if it is ahead of the chain,
+// since the txs in between are still in the mempool. The caller must hold mu.
+func (m *sequenceManager) sync() error {
+	accountNumber, sequence, err := m.fetch()
+	if err != nil {
+		return err
+	}
+
+	m.accountNumber = accountNumber
+	if !m.known || sequence > m.broadcast {
+		m.resetTo(sequence)
+	}
+	m.forgetBefore(sequence)
+	m.known = true
+	m.synced = true
+
+	return nil
+}
+
+// forgetBefore forgets the txs sent with a sequence lower than the given one.
+// The caller must hold mu.
+func (m *sequenceManager) forgetBefore(sequence uint64) {
+	for s := range m.pending {
+		if s < sequence {
+			delete(m.pending, s)
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSequenceManager(t *testing.T) {
+	chainSequence := uint64(5)
+	fetches := 0
+	m := newSequenceManager(func() (uint64, uint64, error) {
+		fetches++
+		return 7, chainSequence, nil
+	})
+
+	// the first reservation syncs from chain, the following ones use the local sequence
+	accountNumber, sequence, err := m.next()
+	require.NoError(t, err)
+	require.Equal(t, uint64(7), accountNumber)
+	require.Equal(t, uint64(5), sequence)
+	for s := uint64(5); s < 8; s++ {
+		r, err := m.reserve()
+		require.NoError(t, err)
+		require.Equal(t, s, r.sequence)
+		m.commit(r, []byte{byte(s)})
+	}
+	require.Equal(t, 1, fetches)
+
+	// an included tx is not rebroadcast
+	m.confirm(5)
+
+	// the node lost the txs from sequence 6 on
+	require.Equal(t, [][]byte{{6}, {7}}, m.gap(6))
+	require.Equal(t, uint64(8), m.sequence)
+
+	// the node lost a tx that is not known anymore
+	m.confirm(6)
+	require.Empty(t, m.gap(6))
+	require.Equal(t, uint64(6), m.sequence)
+	require.Empty(t, m.pending)
+
+	// another signer used the key
+	require.Empty(t, m.gap(10))
+	r, err := m.reserve()
+	require.NoError(t, err)
+	require.Equal(t, uint64(10), r.sequence)
+	m.commit(r, []byte{})
+
+	// too many pending txs trigger a resync, which keeps the local sequence ahead of the chain
+	for s := uint64(11); s <= 10+maxPendingTxs; s++ {
+		r, err := m.reserve()
+		require.NoError(t, err)
+		m.commit(r, []byte{})
+	}
+	chainSequence = 500
+	r, err = m.reserve()
+	require.NoError(t, err)
+	require.Equal(t, 2, fetches)
+	require.Equal(t, uint64(11+maxPendingTxs), r.sequence)
+	require.Len(t, m.pending, 11+maxPendingTxs-500)
+}
+
+func TestSequenceManagerReservations(t *testing.T) {
+	m := newSequenceManager(func() (uint64, uint64, error) {
+		return 7, 5, nil
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	first, err := m.reserve()
+	require.NoError(t, err)
+	second, err := m.reserve()
+	require.NoError(t, err)
+	third, err := m.reserve()
+	require.NoError(t, err)
+	require.Equal(t, []uint64{5, 6, 7}, []uint64{first.sequence, second.sequence, third.sequence})
+
+	// the txs are broadcast in order of sequence
+	thirdTurn := make(chan bool)
+	go func() {
+		ok, err := m.waitTurn(ctx, third)
+		require.NoError(t, err)
+		thirdTurn <- ok
+	}()
+	ok, err := m.waitTurn(ctx, first)
+	require.NoError(t, err)
+	require.True(t, ok)
+	m.commit(first, []byte{5})
+	ok, err = m.waitTurn(ctx, second)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	// the second tx fails, which invalidates the third one
+	m.release(second)
+	require.False(t, <-thirdTurn)
+
+	// so the third tx is signed again with the sequence of the second one
+	again, err := m.reserve()
+	require.NoError(t, err)
+	require.Equal(t, uint64(6), again.sequence)
+	ok, err = m.waitTurn(ctx, again)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	// a tx waiting for its turn gives up with its context
+	later, err := m.reserve()
+	require.NoError(t, err)
+	canceled, cancelWait := context.WithCancel(ctx)
+	cancelWait()
+	_, err = m.waitTurn(canceled, later)
+	require.ErrorIs(t, err, context.Canceled)
+}
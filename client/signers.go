@@ -0,0 +1,256 @@
+package client
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"sync"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// A client signs each tx with one of the keys of its keyring, each key having its own sequences
+// and send statistics. The signer of a tx is, in order of precedence, the key set by WithSigner,
+// the key its msg types are routed to by the key-routes field of the config or SetKeyRoute,
+// and the active key of the client.
+
+// SendStats counts the txs sent with a key since the client was created
+type SendStats struct {
+	// Broadcast is the number of txs accepted by the mempool
+	Broadcast uint64
+	// BroadcastErrors is the number of broadcast attempts that failed, retries included
+	BroadcastErrors uint64
+	// Included is the number of txs included in a block that succeeded
+	Included uint64
+	// Failed is the number of txs included in a block that failed
+	Failed uint64
+	// GasUsed is the gas used by the included txs
+	GasUsed uint64
+	// Fees is the fees paid by the included txs
+	Fees sdk.Coins
+	// LastTxHash is the hash of the last tx accepted by the mempool
+	LastTxHash string
+	// LastError is the last error of a broadcast attempt or of an included tx
+	LastError error
+}
+
+// sendStats accumulates the SendStats of a key
+type sendStats struct {
+	mu    sync.Mutex
+	stats SendStats
+}
+
+func (s *sendStats) recordBroadcast(txHash string, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err != nil {
+		s.stats.BroadcastErrors++
+		s.stats.LastError = err
+		return
+	}
+	s.stats.Broadcast++
+	s.stats.LastTxHash = txHash
+}
+
+func (s *sendStats) recordInclusion(included *IncludedTx, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err != nil {
+		s.stats.Failed++
+		s.stats.LastError = err
+	} else {
+		s.stats.Included++
+	}
+	s.stats.GasUsed += uint64(included.TxResult.GasUsed)
+	s.stats.Fees = s.stats.Fees.Add(paidFees(included.Events)...)
+}
+
+func (s *sendStats) snapshot() SendStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	stats := s.stats
+	stats.Fees = sdk.NewCoins(s.stats.Fees...)
+	return stats
+}
+
+// paidFees returns the fees paid by a tx out of the events of its ante handler
+func paidFees(events []sdk.StringEvent) sdk.Coins {
+	var fees sdk.Coins
+	for _, event := range events {
+		if event.Type != sdk.EventTypeTx {
+			continue
+		}
+		for _, attr := range event.Attributes {
+			if attr.Key != sdk.AttributeKeyFee {
+				continue
+			}
+			if coins, err := sdk.ParseCoinsNormalized(attr.Value); err == nil {
+				fees = fees.Add(coins...)
+			}
+		}
+	}
+	return fees
+}
+
+// SendStats returns the statistics of the txs sent with the key with the given name,
+// and whether the client sent any tx with it
+func (c *Client) SendStats(keyName string) (SendStats, bool) {
+	c.keyMu.RLock()
+	seqs, ok := c.signers[keyName]
+	c.keyMu.RUnlock()
+	if !ok {
+		return SendStats{}, false
+	}
+	return seqs.stats.snapshot(), true
+}
+
+// SendStatsByKey returns the statistics of the txs sent with each key the client used
+func (c *Client) SendStatsByKey() map[string]SendStats {
+	c.keyMu.RLock()
+	defer c.keyMu.RUnlock()
+	stats := make(map[string]SendStats, len(c.signers))
+	for keyName, seqs := range c.signers {
+		stats[keyName] = seqs.stats.snapshot()
+	}
+	return stats
+}
+
+// SetKeyRoute makes the key with the given name sign the txs with msgs of the given type URL,
+// an empty key name removes the route. See MsgTypeURLs.
+func (c *Client) SetKeyRoute(msgTypeURL, keyName string) error {
+	if keyName != "" && !c.provider.KeyExists(keyName) {
+		return fmt.Errorf("key %s not found in the keyring", keyName)
+	}
+
+	c.keyMu.Lock()
+	defer c.keyMu.Unlock()
+	if keyName == "" {
+		delete(c.keyRoutes, msgTypeURL)
+	} else {
+		c.keyRoutes[msgTypeURL] = keyName
+	}
+	return nil
+}
+
+// KeyRoutes returns the key signing the txs of each routed msg type URL
+func (c *Client) KeyRoutes() map[string]string {
+	c.keyMu.RLock()
+	defer c.keyMu.RUnlock()
+	routes := make(map[string]string, len(c.keyRoutes))
+	for msgTypeURL, keyName := range c.keyRoutes {
+		routes[msgTypeURL] = keyName
+	}
+	return routes
+}
+
+// SignerKeys returns the names of the keys the client signed or is ready to sign txs with,
+// the keys of the config being listed once they are in the keyring
+func (c *Client) SignerKeys() []string {
+	c.keyMu.RLock()
+	keyNames := make([]string, 0, len(c.signers))
+	for keyName := range c.signers {
+		keyNames = append(keyNames, keyName)
+	}
+	c.keyMu.RUnlock()
+
+	if c.cfg != nil {
+		for _, keyName := range append([]string{c.cfg.Key}, c.cfg.Keys...) {
+			if keyName != "" && !slices.Contains(keyNames, keyName) && c.provider.KeyExists(keyName) {
+				keyNames = append(keyNames, keyName)
+			}
+		}
+	}
+	sort.Strings(keyNames)
+	return keyNames
+}
+
+// signerKey returns the name of the key signing a tx made of msgs with the given options
+func (c *Client) signerKey(msgs []sdk.Msg, options TxOptions) (string, error) {
+	return c.routedKey(MsgTypeURLs(msgs...), options)
+}
+
+// routedKey returns the name of the key signing a tx made of msgs of the given type URLs
+// with the given options
+func (c *Client) routedKey(msgTypeURLs []string, options TxOptions) (string, error) {
+	if options.Signer != "" {
+		return options.Signer, nil
+	}
+
+	c.keyMu.RLock()
+	defer c.keyMu.RUnlock()
+	keyName := ""
+	for _, msgTypeURL := range msgTypeURLs {
+		route, ok := c.keyRoutes[msgTypeURL]
+		if !ok {
+			continue
+		}
+		if keyName != "" && route != keyName {
+			return "", fmt.Errorf("the msgs of the tx are routed to the different keys %s and %s, set the signer explicitly", keyName, route)
+		}
+		keyName = route
+	}
+	if keyName == "" {
+		keyName = c.provider.PCfg.Key
+	}
+	return keyName, nil
+}
+
+// signerFor returns the sequences of the key signing a tx made of msgs with the given options
+func (c *Client) signerFor(msgs []sdk.Msg, options TxOptions) (*sequenceManager, error) {
+	keyName, err := c.signerKey(msgs, options)
+	if err != nil {
+		return nil, err
+	}
+	return c.keySequences(keyName)
+}
+
+// keySequences returns the sequences of the key with the given name,
+// tracking them from now on if the client did not sign with the key yet
+func (c *Client) keySequences(keyName string) (*sequenceManager, error) {
+	c.keyMu.RLock()
+	seqs, ok := c.signers[keyName]
+	c.keyMu.RUnlock()
+	if ok {
+		return seqs, nil
+	}
+
+	if !c.provider.KeyExists(keyName) {
+		return nil, fmt.Errorf("key %s not found in the keyring", keyName)
+	}
+
+	c.keyMu.Lock()
+	defer c.keyMu.Unlock()
+	if seqs, ok := c.signers[keyName]; ok {
+		return seqs, nil
+	}
+	seqs = c.newKeySequences(keyName)
+	c.signers[keyName] = seqs
+	return seqs, nil
+}
+
+// newKeySequences returns a sequence manager for the key with the given name
+func (c *Client) newKeySequences(keyName string) *sequenceManager {
+	seqs := newSequenceManager(func() (uint64, uint64, error) {
+		return c.fetchAccount(keyName)
+	})
+	seqs.keyName = keyName
+	return seqs
+}
+
+// signerAddress returns the address of the key signing a tx made of msg with the given options
+func (c *Client) signerAddress(msg sdk.Msg, options TxOptions) (string, error) {
+	keyName, err := c.signerKey([]sdk.Msg{msg}, options)
+	if err != nil {
+		return "", err
+	}
+	return c.keyAddress(keyName)
+}
+
+// keyAddress returns the address of the key with the given name
+func (c *Client) keyAddress(keyName string) (string, error) {
+	addr, err := c.provider.GetKeyAddressForKey(keyName)
+	if err != nil {
+		return "", err
+	}
+	return c.provider.EncodeBech32AccAddr(addr)
+}
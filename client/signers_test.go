@@ -0,0 +1,109 @@
+package client
+
+import (
+	"errors"
+	"testing"
+
+	bnblightclienttypes "github.com/Lorenzo-Protocol/lorenzo/v3/x/bnblightclient/types"
+	btclctypes "github.com/Lorenzo-Protocol/lorenzo/v3/x/btclightclient/types"
+	abci "github.com/cometbft/cometbft/abci/types"
+	coretypes "github.com/cometbft/cometbft/rpc/core/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestSignerKeys(t *testing.T) {
+	c := newOfflineTestClient(t)
+	for _, keyName := range []string{"btc", "bnb", "staking"} {
+		_, err := c.CreateKey(keyName)
+		require.NoError(t, err)
+	}
+	require.NoError(t, c.SetActiveKey("staking"))
+
+	btcMsg := &btclctypes.MsgInsertHeaders{}
+	bnbMsg := &bnblightclienttypes.MsgUploadHeaders{}
+	require.NoError(t, c.SetKeyRoute(sdk.MsgTypeURL(btcMsg), "btc"))
+	require.NoError(t, c.SetKeyRoute(sdk.MsgTypeURL(bnbMsg), "bnb"))
+	require.Error(t, c.SetKeyRoute(sdk.MsgTypeURL(bnbMsg), "unknown"))
+
+	signer := func(options TxOptions, msgs ...sdk.Msg) string {
+		keyName, err := c.signerKey(msgs, options)
+		require.NoError(t, err)
+		return keyName
+	}
+	require.Equal(t, "btc", signer(TxOptions{}, btcMsg))
+	require.Equal(t, "bnb", signer(TxOptions{}, bnbMsg))
+	require.Equal(t, "staking", signer(TxOptions{}, &btclctypes.MsgUpdateParams{}))
+	require.Equal(t, "staking", signer(newTxOptions(WithSigner("staking")), btcMsg))
+
+	// msgs routed to different keys need an explicit signer
+	_, err := c.signerKey([]sdk.Msg{btcMsg, bnbMsg}, TxOptions{})
+	require.Error(t, err)
+	require.Equal(t, "bnb", signer(newTxOptions(WithSigner("bnb")), btcMsg, bnbMsg))
+
+	require.NoError(t, c.SetKeyRoute(sdk.MsgTypeURL(btcMsg), ""))
+	require.Equal(t, "staking", signer(TxOptions{}, btcMsg))
+
+	// each key has its own sequences
+	btcSeqs, err := c.keySequences("btc")
+	require.NoError(t, err)
+	bnbSeqs, err := c.signerFor([]sdk.Msg{bnbMsg}, TxOptions{})
+	require.NoError(t, err)
+	require.NotSame(t, btcSeqs, bnbSeqs)
+	require.Equal(t, "bnb", bnbSeqs.keyName)
+	sameSeqs, err := c.keySequences("btc")
+	require.NoError(t, err)
+	require.Same(t, btcSeqs, sameSeqs)
+	_, err = c.keySequences("unknown")
+	require.Error(t, err)
+	require.Equal(t, []string{"bnb", "btc", "staking"}, c.SignerKeys())
+
+	// and its own statistics
+	btcSeqs.stats.recordBroadcast("AB", nil)
+	btcSeqs.stats.recordBroadcast("", errors.New("mempool is full"))
+	btcSeqs.stats.recordInclusion(newIncludedTx(&coretypes.ResultTx{
+		TxResult: abci.ResponseDeliverTx{
+			GasUsed: 1000,
+			Events: []abci.Event{{
+				Type:       sdk.EventTypeTx,
+				Attributes: []abci.EventAttribute{{Key: sdk.AttributeKeyFee, Value: "20alrz"}},
+			}},
+		},
+	}, 0), nil)
+
+	stats, ok := c.SendStats("btc")
+	require.True(t, ok)
+	require.Equal(t, uint64(1), stats.Broadcast)
+	require.Equal(t, uint64(1), stats.BroadcastErrors)
+	require.Equal(t, uint64(1), stats.Included)
+	require.Equal(t, uint64(1000), stats.GasUsed)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("alrz", 20)), stats.Fees)
+	require.Equal(t, "AB", stats.LastTxHash)
+	require.EqualError(t, stats.LastError, "mempool is full")
+
+	byKey := c.SendStatsByKey()
+	require.Len(t, byKey, 3)
+	require.Zero(t, byKey["bnb"].Broadcast)
+	_, ok = c.SendStats("unknown")
+	require.False(t, ok)
+}
+
+func TestNewWithoutKeys(t *testing.T) {
+	cfg := newTestConfig(t)
+	cfg.Keys = []string{"btc"}
+
+	// a query-only client needs no key, and configured keys may be created afterwards
+	c, err := New(cfg, zap.NewNop())
+	require.NoError(t, err)
+	require.Empty(t, c.SignerKeys())
+	_, err = c.CreateKey("btc")
+	require.NoError(t, err)
+	require.Equal(t, []string{"btc"}, c.SignerKeys())
+	require.NoError(t, c.Stop())
+
+	// routes to keys missing from the keyring are still rejected
+	cfg.KeyRoutes = map[string]string{"/cosmos.bank.v1beta1.MsgSend": "unknown"}
+	_, err = New(cfg, zap.NewNop())
+	require.Error(t, err)
+}
@@ -3,7 +3,6 @@ package client
 import (
 	"context"
 	"fmt"
-	"sync"
 
 	"cosmossdk.io/errors"
 	agenttypes "github.com/Lorenzo-Protocol/lorenzo/v3/x/agent/types"
@@ -13,6 +12,7 @@ import (
 	plantypes "github.com/Lorenzo-Protocol/lorenzo/v3/x/plan/types"
 	tokentypes "github.com/Lorenzo-Protocol/lorenzo/v3/x/token/types"
 	"github.com/avast/retry-go/v4"
+	coretypes "github.com/cometbft/cometbft/rpc/core/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/relayer/v2/relayer/chains/cosmos"
 	pv "github.com/cosmos/relayer/v2/relayer/provider"
@@ -30,214 +30,224 @@ func ToProviderMsgs(msgs []sdk.Msg) []pv.RelayerMessage {
 
 // SendMsgToMempool sends a message to the mempool.
 // It does not wait for the messages to be included.
-func (c *Client) SendMsgToMempool(ctx context.Context, msg sdk.Msg) error {
-	return c.SendMsgsToMempool(ctx, []sdk.Msg{msg})
+func (c *Client) SendMsgToMempool(ctx context.Context, msg sdk.Msg, opts ...TxOption) error {
+	return c.SendMsgsToMempool(ctx, []sdk.Msg{msg}, opts...)
 }
 
 // SendMsgsToMempool sends a set of messages to the mempool.
 // It does not wait for the messages to be included.
-func (c *Client) SendMsgsToMempool(ctx context.Context, msgs []sdk.Msg) error {
-	if len(msgs) == 0 {
-		return fmt.Errorf("empty message set provided")
-	}
+// A tx rejected by the node fails with a TxError.
+// With a fee granter, the fee is paid by its fee allowance, see SetFeeGranter.
+func (c *Client) SendMsgsToMempool(ctx context.Context, msgs []sdk.Msg, opts ...TxOption) error {
+	_, err := c.BroadcastMsgs(ctx, msgs, opts...)
+	return err
+}
 
-	relayerMsgs := ToProviderMsgs(msgs)
-	if err := retry.Do(func() error {
-		var sendMsgErr error
-		krErr := c.accessKeyWithLock(func() {
-			sendMsgErr = c.provider.SendMessagesToMempool(ctx, relayerMsgs, "", ctx, []func(*pv.RelayerTxResponse, error){})
-		})
-		if krErr != nil {
-			c.logger.Error("unrecoverable err when submitting the tx, skip retrying", zap.Error(krErr))
-			return retry.Unrecoverable(krErr)
+// ReliablySendMsg reliable sends a message to the chain.
+// TODO: needs tests
+func (c *Client) ReliablySendMsg(ctx context.Context, msg sdk.Msg, expectedErrors []*errors.Error, unrecoverableErrors []*errors.Error, opts ...TxOption) (*pv.RelayerTxResponse, error) {
+	return c.ReliablySendMsgs(ctx, []sdk.Msg{msg}, expectedErrors, unrecoverableErrors, opts...)
+}
+
+// ReliablySendMsgs reliably sends a list of messages to the chain and waits for them to be included,
+// for at most the block timeout of the config.
+// Concurrent calls are safe: each tx gets the next account sequence of its signer, see SignerKeys.
+// In dry-run mode, the tx is not broadcast and the response holds the events of its simulation.
+// In authz mode, msgs are executed on behalf of the authz granter, see SetAuthzGranter.
+// With a fee granter, the fee is paid by its fee allowance and a tx failing because the allowance
+// is missing, exhausted or expired is not retried, see SetFeeGranter.
+// A tx rejected by the node or failing in a block returns a TxError, which matches
+// expectedErrors and unrecoverableErrors with errors.Is.
+// TODO: needs tests
+func (c *Client) ReliablySendMsgs(ctx context.Context, msgs []sdk.Msg, expectedErrors []*errors.Error, unrecoverableErrors []*errors.Error, opts ...TxOption) (*pv.RelayerTxResponse, error) {
+	options := newTxOptions(opts...)
+	if c.isDryRun(options) {
+		dryRunRes, err := c.dryRunMsgs(ctx, msgs, options)
+		if err != nil {
+			if errorContained(err, expectedErrors) {
+				return nil, nil
+			}
+			return nil, err
 		}
-		return sendMsgErr
-	}, retry.Context(ctx), rtyAtt, rtyDel, rtyErr, retry.OnRetry(func(n uint, err error) {
-		c.logger.Debug("retrying", zap.Uint("attemp", n+1), zap.Uint("max_attempts", rtyAttNum), zap.Error(err))
-	})); err != nil {
-		return err
+		return dryRunRes.relayerTxResponse(), nil
 	}
 
-	return nil
-}
+	seqs, err := c.signerFor(msgs, options)
+	if err != nil {
+		return nil, err
+	}
+	res, sequence, err := c.sendMsgs(ctx, seqs, msgs, expectedErrors, unrecoverableErrors, options)
+	if err != nil {
+		return nil, err
+	}
 
-// ReliablySendMsg reliable sends a message to the chain.
-// It utilizes a file lock as well as a keyring lock to ensure atomic access.
-// TODO: needs tests
-func (c *Client) ReliablySendMsg(ctx context.Context, msg sdk.Msg, expectedErrors []*errors.Error, unrecoverableErrors []*errors.Error) (*pv.RelayerTxResponse, error) {
-	return c.ReliablySendMsgs(ctx, []sdk.Msg{msg}, expectedErrors, unrecoverableErrors)
-}
+	if res == nil {
+		// this case could happen if the error within the retry is an expected error
+		return nil, nil
+	}
 
-// ReliablySendMsgs reliably sends a list of messages to the chain.
-// It utilizes a file lock as well as a keyring lock to ensure atomic access.
-// TODO: needs tests
-func (c *Client) ReliablySendMsgs(ctx context.Context, msgs []sdk.Msg, expectedErrors []*errors.Error, unrecoverableErrors []*errors.Error) (*pv.RelayerTxResponse, error) {
-	var (
-		rlyResp     *pv.RelayerTxResponse
-		callbackErr error
-		wg          sync.WaitGroup
-	)
+	included, err := c.WaitForTx(ctx, res.Hash.String())
+	if included == nil {
+		return nil, err
+	}
+	seqs.confirm(sequence)
+	seqs.stats.recordInclusion(included, err)
 
-	callback := func(rtr *pv.RelayerTxResponse, err error) {
-		rlyResp = rtr
-		callbackErr = err
-		wg.Done()
+	rlyResp := newRelayerTxResponse(included.ResultTx)
+	if err != nil {
+		if errorContained(err, expectedErrors) {
+			return nil, nil
+		}
+		err = c.wrapFeeGrantError(err, options)
+		c.logger.Error("tx failed", zap.String("tx_hash", rlyResp.TxHash), zap.Int64("height", rlyResp.Height), zap.Error(err))
+		return rlyResp, err
 	}
 
-	wg.Add(1)
+	return rlyResp, nil
+}
 
-	// convert message type
-	relayerMsgs := ToProviderMsgs(msgs)
+// sendMsgs broadcasts a tx made of msgs signed with the key of seqs to the mempool, retrying according to the retry policy
+// of the client, and returns the broadcast result along with the sequence of the tx.
+// Errors matching expectedErrors return a nil result, errors matching unrecoverableErrors
+// are not retried.
+func (c *Client) sendMsgs(ctx context.Context, seqs *sequenceManager, msgs []sdk.Msg, expectedErrors []*errors.Error, unrecoverableErrors []*errors.Error, options TxOptions) (*coretypes.ResultBroadcastTx, uint64, error) {
+	if len(msgs) == 0 {
+		return nil, 0, fmt.Errorf("empty message set provided")
+	}
+	msgs, err := c.wrapAuthz(seqs.keyName, msgs, options)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var (
+		res      *coretypes.ResultBroadcastTx
+		sequence uint64
+	)
 
 	// TODO: consider using Lorenzo's retry package
 	if err := retry.Do(func() error {
 		var sendMsgErr error
-		krErr := c.accessKeyWithLock(func() {
-			sendMsgErr = c.provider.SendMessagesToMempool(ctx, relayerMsgs, "", ctx, []func(*pv.RelayerTxResponse, error){callback})
-		})
-		if krErr != nil {
-			c.logger.Error("unrecoverable err when submitting the tx, skip retrying", zap.Error(krErr))
-			return retry.Unrecoverable(krErr)
-		}
+		res, sequence, sendMsgErr = c.broadcastMsgs(ctx, seqs, msgs, options)
 		if sendMsgErr != nil {
 			if errorContained(sendMsgErr, unrecoverableErrors) {
-				c.logger.Error("unrecoverable err when submitting the tx, skip retrying", zap.Error(sendMsgErr))
+				c.logger.Error("unrecoverable err when submitting the tx, skip retrying", zap.String("endpoint", c.ActiveEndpoint()), zap.Error(sendMsgErr))
 				return retry.Unrecoverable(sendMsgErr)
 			}
 			if errorContained(sendMsgErr, expectedErrors) {
-				// this is necessary because if err is returned
-				// the callback function will not be executed so
-				// that the inside wg.Done will not be executed
-				wg.Done()
-				c.logger.Error("expected err when submitting the tx, skip retrying", zap.Error(sendMsgErr))
+				c.logger.Error("expected err when submitting the tx, skip retrying", zap.String("endpoint", c.ActiveEndpoint()), zap.Error(sendMsgErr))
+				res = nil
 				return nil
 			}
+			if isFeeGrantError(sendMsgErr) {
+				// retrying cannot help until the fee allowance is granted again
+				return retry.Unrecoverable(c.wrapFeeGrantError(sendMsgErr, options))
+			}
 			return sendMsgErr
 		}
 		return nil
-	}, retry.Context(ctx), rtyAtt, rtyDel, rtyErr, retry.OnRetry(func(n uint, err error) {
-		c.logger.Debug("retrying", zap.Uint("attemp", n+1), zap.Uint("max_attempts", rtyAttNum), zap.Error(err))
-	})); err != nil {
-		return nil, err
+	}, c.GetRetryPolicy().retryOptions(ctx, c.logger)...); err != nil {
+		return nil, 0, err
 	}
 
-	wg.Wait()
-
-	if callbackErr != nil {
-		if errorContained(callbackErr, expectedErrors) {
-			return nil, nil
-		}
-		return nil, callbackErr
-	}
-
-	if rlyResp == nil {
-		// this case could happen if the error within the retry is an expected error
-		return nil, nil
-	}
-
-	if rlyResp.Code != 0 {
-		return rlyResp, fmt.Errorf("transaction failed with code: %d", rlyResp.Code)
-	}
-
-	return rlyResp, nil
+	return res, sequence, nil
 }
 
-func (c *Client) InsertHeaders(ctx context.Context, msg *btclctypes.MsgInsertHeaders) (*pv.RelayerTxResponse, error) {
-	return c.ReliablySendMsg(ctx, msg, []*errors.Error{}, []*errors.Error{})
+func (c *Client) InsertHeaders(ctx context.Context, msg *btclctypes.MsgInsertHeaders, opts ...TxOption) (*pv.RelayerTxResponse, error) {
+	return c.ReliablySendMsg(ctx, msg, []*errors.Error{}, []*errors.Error{}, opts...)
 }
 
-func (c *Client) CreateBTCStakingWithBTCProof(ctx context.Context, msg *btcstakingtypes.MsgCreateBTCStaking) (*pv.RelayerTxResponse, error) {
-	return c.ReliablySendMsg(ctx, msg, []*errors.Error{}, []*errors.Error{})
+func (c *Client) CreateBTCStakingWithBTCProof(ctx context.Context, msg *btcstakingtypes.MsgCreateBTCStaking, opts ...TxOption) (*pv.RelayerTxResponse, error) {
+	return c.ReliablySendMsg(ctx, msg, []*errors.Error{}, []*errors.Error{}, opts...)
 }
 
-func (c *Client) AddAgent(ctx context.Context, msg *agenttypes.MsgAddAgent) (*pv.RelayerTxResponse, error) {
-	return c.ReliablySendMsg(ctx, msg, []*errors.Error{}, []*errors.Error{})
+func (c *Client) AddAgent(ctx context.Context, msg *agenttypes.MsgAddAgent, opts ...TxOption) (*pv.RelayerTxResponse, error) {
+	return c.ReliablySendMsg(ctx, msg, []*errors.Error{}, []*errors.Error{}, opts...)
 }
 
-func (c *Client) EditAgent(ctx context.Context, msg *agenttypes.MsgEditAgent) (*pv.RelayerTxResponse, error) {
-	return c.ReliablySendMsg(ctx, msg, []*errors.Error{}, []*errors.Error{})
+func (c *Client) EditAgent(ctx context.Context, msg *agenttypes.MsgEditAgent, opts ...TxOption) (*pv.RelayerTxResponse, error) {
+	return c.ReliablySendMsg(ctx, msg, []*errors.Error{}, []*errors.Error{}, opts...)
 }
 
-func (c *Client) RemoveAgent(ctx context.Context, msg *agenttypes.MsgRemoveAgent) (*pv.RelayerTxResponse, error) {
-	return c.ReliablySendMsg(ctx, msg, []*errors.Error{}, []*errors.Error{})
+func (c *Client) RemoveAgent(ctx context.Context, msg *agenttypes.MsgRemoveAgent, opts ...TxOption) (*pv.RelayerTxResponse, error) {
+	return c.ReliablySendMsg(ctx, msg, []*errors.Error{}, []*errors.Error{}, opts...)
 }
 
 /**************************
 *******	Plan Module ********
 ************************/
 
-func (c *Client) UpgradePlan(ctx context.Context, msg *plantypes.MsgUpgradePlan) (*pv.RelayerTxResponse, error) {
-	return c.ReliablySendMsg(ctx, msg, []*errors.Error{}, []*errors.Error{})
+func (c *Client) UpgradePlan(ctx context.Context, msg *plantypes.MsgUpgradePlan, opts ...TxOption) (*pv.RelayerTxResponse, error) {
+	return c.ReliablySendMsg(ctx, msg, []*errors.Error{}, []*errors.Error{}, opts...)
 }
 
-func (c *Client) CreatePlan(ctx context.Context, msg *plantypes.MsgCreatePlan) (*pv.RelayerTxResponse, error) {
-	return c.ReliablySendMsg(ctx, msg, []*errors.Error{}, []*errors.Error{})
+func (c *Client) CreatePlan(ctx context.Context, msg *plantypes.MsgCreatePlan, opts ...TxOption) (*pv.RelayerTxResponse, error) {
+	return c.ReliablySendMsg(ctx, msg, []*errors.Error{}, []*errors.Error{}, opts...)
 }
 
-func (c *Client) SetPlanMerkleRoot(ctx context.Context, msg *plantypes.MsgSetMerkleRoot) (*pv.RelayerTxResponse, error) {
-	return c.ReliablySendMsg(ctx, msg, []*errors.Error{}, []*errors.Error{})
+func (c *Client) SetPlanMerkleRoot(ctx context.Context, msg *plantypes.MsgSetMerkleRoot, opts ...TxOption) (*pv.RelayerTxResponse, error) {
+	return c.ReliablySendMsg(ctx, msg, []*errors.Error{}, []*errors.Error{}, opts...)
 }
 
-func (c *Client) Claims(ctx context.Context, msg *plantypes.MsgClaims) (*pv.RelayerTxResponse, error) {
-	return c.ReliablySendMsg(ctx, msg, []*errors.Error{}, []*errors.Error{})
+func (c *Client) Claims(ctx context.Context, msg *plantypes.MsgClaims, opts ...TxOption) (*pv.RelayerTxResponse, error) {
+	return c.ReliablySendMsg(ctx, msg, []*errors.Error{}, []*errors.Error{}, opts...)
 }
 
-func (c *Client) CreateYAT(ctx context.Context, msg *plantypes.MsgCreateYAT) (*pv.RelayerTxResponse, error) {
-	return c.ReliablySendMsg(ctx, msg, []*errors.Error{}, []*errors.Error{})
+func (c *Client) CreateYAT(ctx context.Context, msg *plantypes.MsgCreateYAT, opts ...TxOption) (*pv.RelayerTxResponse, error) {
+	return c.ReliablySendMsg(ctx, msg, []*errors.Error{}, []*errors.Error{}, opts...)
 }
 
-func (c *Client) UpdatePlanStatus(ctx context.Context, msg *plantypes.MsgUpdatePlanStatus) (*pv.RelayerTxResponse, error) {
-	return c.ReliablySendMsg(ctx, msg, []*errors.Error{}, []*errors.Error{})
+func (c *Client) UpdatePlanStatus(ctx context.Context, msg *plantypes.MsgUpdatePlanStatus, opts ...TxOption) (*pv.RelayerTxResponse, error) {
+	return c.ReliablySendMsg(ctx, msg, []*errors.Error{}, []*errors.Error{}, opts...)
 }
 
-func (c *Client) SetMinter(ctx context.Context, msg *plantypes.MsgSetMinter) (*pv.RelayerTxResponse, error) {
-	return c.ReliablySendMsg(ctx, msg, []*errors.Error{}, []*errors.Error{})
+func (c *Client) SetMinter(ctx context.Context, msg *plantypes.MsgSetMinter, opts ...TxOption) (*pv.RelayerTxResponse, error) {
+	return c.ReliablySendMsg(ctx, msg, []*errors.Error{}, []*errors.Error{}, opts...)
 }
 
-func (c *Client) RemoveMinter(ctx context.Context, msg *plantypes.MsgRemoveMinter) (*pv.RelayerTxResponse, error) {
-	return c.ReliablySendMsg(ctx, msg, []*errors.Error{}, []*errors.Error{})
+func (c *Client) RemoveMinter(ctx context.Context, msg *plantypes.MsgRemoveMinter, opts ...TxOption) (*pv.RelayerTxResponse, error) {
+	return c.ReliablySendMsg(ctx, msg, []*errors.Error{}, []*errors.Error{}, opts...)
 }
 
 // ======= Token Module =========
 
-func (c *Client) RegisterCoin(ctx context.Context, msg *tokentypes.MsgRegisterCoin) (*pv.RelayerTxResponse, error) {
-	return c.ReliablySendMsg(ctx, msg, []*errors.Error{}, []*errors.Error{})
+func (c *Client) RegisterCoin(ctx context.Context, msg *tokentypes.MsgRegisterCoin, opts ...TxOption) (*pv.RelayerTxResponse, error) {
+	return c.ReliablySendMsg(ctx, msg, []*errors.Error{}, []*errors.Error{}, opts...)
 }
 
-func (c *Client) RegisterERC20(ctx context.Context, msg *tokentypes.MsgRegisterERC20) (*pv.RelayerTxResponse, error) {
-	return c.ReliablySendMsg(ctx, msg, []*errors.Error{}, []*errors.Error{})
+func (c *Client) RegisterERC20(ctx context.Context, msg *tokentypes.MsgRegisterERC20, opts ...TxOption) (*pv.RelayerTxResponse, error) {
+	return c.ReliablySendMsg(ctx, msg, []*errors.Error{}, []*errors.Error{}, opts...)
 }
 
-func (c *Client) ToggleConversion(ctx context.Context, msg *tokentypes.MsgToggleConversion) (*pv.RelayerTxResponse, error) {
-	return c.ReliablySendMsg(ctx, msg, []*errors.Error{}, []*errors.Error{})
+func (c *Client) ToggleConversion(ctx context.Context, msg *tokentypes.MsgToggleConversion, opts ...TxOption) (*pv.RelayerTxResponse, error) {
+	return c.ReliablySendMsg(ctx, msg, []*errors.Error{}, []*errors.Error{}, opts...)
 }
 
-func (c *Client) TokenUpdateParams(ctx context.Context, msg *tokentypes.MsgUpdateParams) (*pv.RelayerTxResponse, error) {
-	return c.ReliablySendMsg(ctx, msg, []*errors.Error{}, []*errors.Error{})
+func (c *Client) TokenUpdateParams(ctx context.Context, msg *tokentypes.MsgUpdateParams, opts ...TxOption) (*pv.RelayerTxResponse, error) {
+	return c.ReliablySendMsg(ctx, msg, []*errors.Error{}, []*errors.Error{}, opts...)
 }
 
-func (c *Client) ConvertCoin(ctx context.Context, msg *tokentypes.MsgConvertCoin) (*pv.RelayerTxResponse, error) {
-	return c.ReliablySendMsg(ctx, msg, []*errors.Error{}, []*errors.Error{})
+func (c *Client) ConvertCoin(ctx context.Context, msg *tokentypes.MsgConvertCoin, opts ...TxOption) (*pv.RelayerTxResponse, error) {
+	return c.ReliablySendMsg(ctx, msg, []*errors.Error{}, []*errors.Error{}, opts...)
 }
 
-func (c *Client) ConvertERC20(ctx context.Context, msg *tokentypes.MsgConvertERC20) (*pv.RelayerTxResponse, error) {
-	return c.ReliablySendMsg(ctx, msg, []*errors.Error{}, []*errors.Error{})
+func (c *Client) ConvertERC20(ctx context.Context, msg *tokentypes.MsgConvertERC20, opts ...TxOption) (*pv.RelayerTxResponse, error) {
+	return c.ReliablySendMsg(ctx, msg, []*errors.Error{}, []*errors.Error{}, opts...)
 }
 
 // ======= BNB light client Module =========
 
-func (c *Client) BNBUploadHeaders(ctx context.Context, msg *bnblightclienttypes.MsgUploadHeaders) (*pv.RelayerTxResponse, error) {
-	return c.ReliablySendMsg(ctx, msg, []*errors.Error{}, []*errors.Error{})
+func (c *Client) BNBUploadHeaders(ctx context.Context, msg *bnblightclienttypes.MsgUploadHeaders, opts ...TxOption) (*pv.RelayerTxResponse, error) {
+	return c.ReliablySendMsg(ctx, msg, []*errors.Error{}, []*errors.Error{}, opts...)
 }
 
-func (c *Client) BNBUpdateHeader(ctx context.Context, msg *bnblightclienttypes.MsgUpdateHeader) (*pv.RelayerTxResponse, error) {
-	return c.ReliablySendMsg(ctx, msg, []*errors.Error{}, []*errors.Error{})
+func (c *Client) BNBUpdateHeader(ctx context.Context, msg *bnblightclienttypes.MsgUpdateHeader, opts ...TxOption) (*pv.RelayerTxResponse, error) {
+	return c.ReliablySendMsg(ctx, msg, []*errors.Error{}, []*errors.Error{}, opts...)
 }
 
-func (c *Client) BNBUpdateParams(ctx context.Context, msg *btcstakingtypes.MsgUpdateParams) (*pv.RelayerTxResponse, error) {
-	return c.ReliablySendMsg(ctx, msg, []*errors.Error{}, []*errors.Error{})
+func (c *Client) BNBUpdateParams(ctx context.Context, msg *btcstakingtypes.MsgUpdateParams, opts ...TxOption) (*pv.RelayerTxResponse, error) {
+	return c.ReliablySendMsg(ctx, msg, []*errors.Error{}, []*errors.Error{}, opts...)
 }
 
-func (c *Client) CreateBTCBStaking(ctx context.Context, msg *btcstakingtypes.MsgCreateBTCBStaking) (*pv.RelayerTxResponse, error) {
-	return c.ReliablySendMsg(ctx, msg, []*errors.Error{}, []*errors.Error{})
+func (c *Client) CreateBTCBStaking(ctx context.Context, msg *btcstakingtypes.MsgCreateBTCBStaking, opts ...TxOption) (*pv.RelayerTxResponse, error) {
+	return c.ReliablySendMsg(ctx, msg, []*errors.Error{}, []*errors.Error{}, opts...)
 }
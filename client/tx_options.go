@@ -0,0 +1,118 @@
+package client
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// TxOptions overrides the settings of the client for a single tx
+type TxOptions struct {
+	// GasLimit is the gas limit of the tx, 0 estimates it by simulating the tx
+	GasLimit uint64
+	// GasAdjustment multiplies the simulated gas to get the gas limit, 0 uses the one of the config
+	GasAdjustment float64
+	// GasPrices computes the fee out of the gas limit, nil uses the gas prices of the config
+	GasPrices sdk.DecCoins
+	// Fees is the fee of the tx, which takes precedence over the gas prices
+	Fees sdk.Coins
+	// DryRun validates, signs and simulates the tx without broadcasting it
+	DryRun bool
+	// AuthzGranter executes the msgs on behalf of the given granter, overriding the one of the client
+	AuthzGranter string
+	// NoAuthz sends the msgs as they are, even if the client has an authz granter
+	NoAuthz bool
+	// FeeGranter pays the fee of the tx through a fee allowance, overriding the one of the client
+	FeeGranter string
+	// NoFeeGrant makes the client key pay the fee of the tx, even if the client has a fee granter
+	NoFeeGrant bool
+	// Signer is the name of the key signing the tx, overriding the key routes and the active key
+	Signer string
+	// EIP712 signs the tx in the legacy EIP-712 sign mode, which requires an eth_secp256k1 key
+	EIP712 bool
+}
+
+type TxOption func(*TxOptions)
+
+// WithGasLimit sends the tx with the given gas limit instead of simulating it
+func WithGasLimit(gasLimit uint64) TxOption {
+	return func(options *TxOptions) {
+		options.GasLimit = gasLimit
+	}
+}
+
+// WithGasAdjustment multiplies the simulated gas by the given factor to get the gas limit
+func WithGasAdjustment(gasAdjustment float64) TxOption {
+	return func(options *TxOptions) {
+		options.GasAdjustment = gasAdjustment
+	}
+}
+
+// WithGasPrices computes the fee of the tx out of the given gas prices
+func WithGasPrices(gasPrices sdk.DecCoins) TxOption {
+	return func(options *TxOptions) {
+		options.GasPrices = gasPrices
+	}
+}
+
+// WithFees sends the tx with the given fee
+func WithFees(fees sdk.Coins) TxOption {
+	return func(options *TxOptions) {
+		options.Fees = fees
+	}
+}
+
+// WithDryRun validates, signs and simulates the tx without broadcasting it, see Client.DryRun
+func WithDryRun() TxOption {
+	return func(options *TxOptions) {
+		options.DryRun = true
+	}
+}
+
+// WithAuthzGranter wraps the msgs of the tx in a MsgExec to execute them on behalf of granter
+func WithAuthzGranter(granter string) TxOption {
+	return func(options *TxOptions) {
+		options.AuthzGranter = granter
+	}
+}
+
+// WithoutAuthz sends the msgs of the tx as they are, even if the client has an authz granter
+func WithoutAuthz() TxOption {
+	return func(options *TxOptions) {
+		options.NoAuthz = true
+	}
+}
+
+// WithFeeGranter makes a fee allowance of granter pay the fee of the tx
+func WithFeeGranter(granter string) TxOption {
+	return func(options *TxOptions) {
+		options.FeeGranter = granter
+	}
+}
+
+// WithoutFeeGrant makes the client key pay the fee of the tx, even if the client has a fee granter
+func WithoutFeeGrant() TxOption {
+	return func(options *TxOptions) {
+		options.NoFeeGrant = true
+	}
+}
+
+// WithSigner signs the tx with the key of the keyring with the given name
+func WithSigner(keyName string) TxOption {
+	return func(options *TxOptions) {
+		options.Signer = keyName
+	}
+}
+
+// WithEIP712 signs the tx in the legacy EIP-712 sign mode, see Client.SetEIP712
+func WithEIP712() TxOption {
+	return func(options *TxOptions) {
+		options.EIP712 = true
+	}
+}
+
+func newTxOptions(opts ...TxOption) TxOptions {
+	var options TxOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return options
+}
@@ -0,0 +1,297 @@
+package client
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	coretypes "github.com/cometbft/cometbft/rpc/core/types"
+	"github.com/cometbft/cometbft/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"go.uber.org/zap"
+)
+
+const (
+	// defaultBroadcastWaitTimeout bounds the wait for a tx to be included in a block
+	// unless the config sets a block timeout
+	defaultBroadcastWaitTimeout = 10 * time.Minute
+	// txPollInterval is the initial interval between two lookups of a tx waiting for inclusion
+	txPollInterval = time.Second
+	// maxTxPollInterval bounds the interval between two lookups, which doubles as long as
+	// the tx is not found or no new block is built
+	maxTxPollInterval = 15 * time.Second
+)
+
+// WaitOptions configures how WaitForTx waits for a tx to be included in a block
+type WaitOptions struct {
+	// Timeout bounds the whole wait, confirmations included
+	Timeout time.Duration
+	// Confirmations is the number of blocks to wait for on top of the block including the tx
+	Confirmations int64
+	// PollInterval is the initial interval between two lookups of the tx, or of the latest height
+	// while waiting for confirmations. It doubles up to 15s as long as nothing changes.
+	PollInterval time.Duration
+}
+
+type WaitOption func(*WaitOptions)
+
+// WithWaitTimeout bounds the wait by the given timeout instead of the block timeout of the config
+func WithWaitTimeout(timeout time.Duration) WaitOption {
+	return func(options *WaitOptions) {
+		options.Timeout = timeout
+	}
+}
+
+// WithConfirmations waits for the given number of blocks on top of the block including the tx
+func WithConfirmations(confirmations int64) WaitOption {
+	return func(options *WaitOptions) {
+		options.Confirmations = confirmations
+	}
+}
+
+// WithPollInterval sets the initial interval between two lookups of the tx or of the latest height
+func WithPollInterval(interval time.Duration) WaitOption {
+	return func(options *WaitOptions) {
+		options.PollInterval = interval
+	}
+}
+
+// newWaitOptions returns the wait options of the client overridden by opts.
+// The wait is bounded by the block timeout of the config, if set.
+func (c *Client) newWaitOptions(opts ...WaitOption) WaitOptions {
+	options := WaitOptions{
+		Timeout:      defaultBroadcastWaitTimeout,
+		PollInterval: txPollInterval,
+	}
+	if c.cfg.BlockTimeout > 0 {
+		options.Timeout = c.cfg.BlockTimeout
+	}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return options
+}
+
+// IncludedTx is a tx included in a block
+type IncludedTx struct {
+	*coretypes.ResultTx
+	// Events are the events emitted by the tx, in order
+	Events []sdk.StringEvent
+	// Confirmations is the number of blocks built on top of the block including the tx
+	// when the wait returned, 0 unless confirmations were requested
+	Confirmations int64
+}
+
+func newIncludedTx(res *coretypes.ResultTx, confirmations int64) *IncludedTx {
+	return &IncludedTx{
+		ResultTx:      res,
+		Events:        stringifyEvents(res.TxResult.Events),
+		Confirmations: confirmations,
+	}
+}
+
+// BroadcastMsg is like BroadcastMsgs but for a single message
+func (c *Client) BroadcastMsg(ctx context.Context, msg sdk.Msg, opts ...TxOption) (string, error) {
+	return c.BroadcastMsgs(ctx, []sdk.Msg{msg}, opts...)
+}
+
+// BroadcastMsgs sends a tx made of msgs to the mempool and returns its hash as soon as
+// the node accepted it. Use WaitForTx to wait for the tx to be included in a block.
+// In dry-run mode, the tx is not broadcast and the returned hash is the one it would have.
+// A tx rejected by the node fails with a TxError.
+func (c *Client) BroadcastMsgs(ctx context.Context, msgs []sdk.Msg, opts ...TxOption) (string, error) {
+	options := newTxOptions(opts...)
+	if c.isDryRun(options) {
+		dryRunRes, err := c.dryRunMsgs(ctx, msgs, options)
+		if err != nil {
+			return "", err
+		}
+		return dryRunRes.TxHash, nil
+	}
+
+	seqs, err := c.signerFor(msgs, options)
+	if err != nil {
+		return "", err
+	}
+	res, _, err := c.sendMsgs(ctx, seqs, msgs, nil, nil, options)
+	if err != nil || res == nil {
+		return "", err
+	}
+	return res.Hash.String(), nil
+}
+
+// WaitForTx waits for the tx with the given hex encoded hash to be included in a block,
+// then for the requested number of confirmations. When the RPC client is running, the tx is
+// awaited through a subscription to its inclusion and the node is only polled as a fallback,
+// in case the subscription misses it. Otherwise the node is polled with a growing interval.
+// A tx that failed in the block is returned along with a TxError.
+func (c *Client) WaitForTx(ctx context.Context, hash string, opts ...WaitOption) (*IncludedTx, error) {
+	options := c.newWaitOptions(opts...)
+
+	hashBytes, err := hex.DecodeString(hash)
+	if err != nil {
+		return nil, fmt.Errorf("invalid tx hash %s: %w", hash, err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, options.Timeout)
+	defer cancel()
+
+	res, err := c.waitForInclusion(ctx, hashBytes, options.PollInterval)
+	if err != nil {
+		return nil, fmt.Errorf("failed to wait for tx %s to be included in a block: %w", hash, err)
+	}
+
+	var confirmations int64
+	if options.Confirmations > 0 {
+		confirmations, err = c.waitForConfirmations(ctx, res.Height, options.Confirmations, options.PollInterval)
+		if err != nil {
+			return nil, fmt.Errorf("failed to wait for %d confirmations of tx %s: %w", options.Confirmations, hash, err)
+		}
+	}
+
+	included := newIncludedTx(res, confirmations)
+	if res.TxResult.Code != 0 {
+		return included, newDeliverTxError(res)
+	}
+	return included, nil
+}
+
+// waitForInclusion returns the tx with the given hash once it is included in a block
+func (c *Client) waitForInclusion(ctx context.Context, hash []byte, pollInterval time.Duration) (*coretypes.ResultTx, error) {
+	events, unsubscribe := c.subscribeTx(ctx, hash)
+	defer unsubscribe()
+
+	// the tx may be included before the subscription, so it is looked up once in any case,
+	// and then only seldom as long as the subscription is open
+	poll := true
+	backoff := newPollBackoff(pollInterval)
+	if events != nil {
+		backoff = newPollBackoff(backoff.max)
+	}
+
+	for {
+		if poll {
+			res, err := c.GetTxWithContext(ctx, hash)
+			if err == nil {
+				return res, nil
+			}
+			if strings.Contains(err.Error(), "transaction indexing is disabled") {
+				if events == nil {
+					return nil, fmt.Errorf("cannot determine success/failure of tx because transaction indexing is disabled on rpc url")
+				}
+				poll = false
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case event, ok := <-events:
+			if !ok {
+				// the subscription was closed, fall back to polling
+				events = nil
+				poll = true
+				backoff = newPollBackoff(pollInterval)
+				continue
+			}
+			if data, ok := event.Data.(types.EventDataTx); ok {
+				return &coretypes.ResultTx{
+					Hash:     hash,
+					Height:   data.Height,
+					Index:    data.Index,
+					TxResult: data.Result,
+					Tx:       data.Tx,
+				}, nil
+			}
+		case <-time.After(backoff.next()):
+		}
+	}
+}
+
+// subscribeTx subscribes to the inclusion of the tx with the given hash if the RPC client
+// is running, and returns a nil channel otherwise
+func (c *Client) subscribeTx(ctx context.Context, hash []byte) (<-chan coretypes.ResultEvent, func()) {
+	if !c.RPCClient.IsRunning() {
+		return nil, func() {}
+	}
+
+	subscriber := fmt.Sprintf("lorenzo-sdk-tx-%X", hash)
+	query := fmt.Sprintf("%s='%s' AND %s='%X'", types.EventTypeKey, types.EventTx, types.TxHashKey, hash)
+	out, err := c.RPCClient.Subscribe(ctx, subscriber, query)
+	if err != nil {
+		c.logger.Debug("failed to subscribe to tx, polling it instead", zap.String("query", query), zap.Error(err))
+		return nil, func() {}
+	}
+
+	return out, func() {
+		unsubscribeCtx, cancel := context.WithTimeout(context.Background(), c.timeout)
+		defer cancel()
+		if err := c.RPCClient.Unsubscribe(unsubscribeCtx, subscriber, query); err != nil {
+			c.logger.Debug("failed to unsubscribe from tx", zap.String("query", query), zap.Error(err))
+		}
+	}
+}
+
+// waitForConfirmations waits for the given number of blocks on top of the given height,
+// and returns the number of blocks there actually are
+func (c *Client) waitForConfirmations(ctx context.Context, height, confirmations int64, pollInterval time.Duration) (int64, error) {
+	backoff := newPollBackoff(pollInterval)
+	latest := height
+	for {
+		status, err := c.GetStatusWithContext(ctx)
+		if err == nil {
+			if n := status.SyncInfo.LatestBlockHeight - height; n >= confirmations {
+				return n, nil
+			}
+			if status.SyncInfo.LatestBlockHeight > latest {
+				// blocks are being built, look again after the initial interval
+				latest = status.SyncInfo.LatestBlockHeight
+				backoff.reset()
+			}
+		} else if ctx.Err() == nil {
+			c.logger.Debug("failed to get the latest height", zap.Error(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		case <-time.After(backoff.next()):
+		}
+	}
+}
+
+// pollBackoff is the interval between two lookups of the node, doubling after every lookup
+// up to maxTxPollInterval, or the initial interval if larger
+type pollBackoff struct {
+	initial  time.Duration
+	interval time.Duration
+	max      time.Duration
+}
+
+func newPollBackoff(initial time.Duration) *pollBackoff {
+	if initial <= 0 {
+		initial = txPollInterval
+	}
+	b := &pollBackoff{initial: initial, interval: initial, max: maxTxPollInterval}
+	if initial > b.max {
+		b.max = initial
+	}
+	return b
+}
+
+// next returns the interval to wait before the next lookup, and doubles the following one
+func (b *pollBackoff) next() time.Duration {
+	interval := b.interval
+	b.interval *= 2
+	if b.interval > b.max {
+		b.interval = b.max
+	}
+	return interval
+}
+
+// reset makes the next interval the initial one
+func (b *pollBackoff) reset() {
+	b.interval = b.initial
+}
@@ -0,0 +1,228 @@
+package client
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	rpcclient "github.com/cometbft/cometbft/rpc/client"
+	coretypes "github.com/cometbft/cometbft/rpc/core/types"
+	"github.com/cometbft/cometbft/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Lorenzo-Protocol/lorenzo-sdk/v3/config"
+	"github.com/Lorenzo-Protocol/lorenzo-sdk/v3/query"
+)
+
+// fakeWaitNode is a node including a tx after a number of lookups, optionally through a subscription
+type fakeWaitNode struct {
+	rpcclient.Client
+
+	mu      sync.Mutex
+	running bool
+	events  chan coretypes.ResultEvent
+	// foundAfter is the number of lookups of the tx before it is found, -1 for never
+	foundAfter int
+	txErr      error
+	code       uint32
+	lookups    int
+	// heights are the latest heights returned by the successive status requests
+	heights  []int64
+	statuses int
+}
+
+func (f *fakeWaitNode) IsRunning() bool {
+	return f.running
+}
+
+func (f *fakeWaitNode) Subscribe(context.Context, string, string, ...int) (<-chan coretypes.ResultEvent, error) {
+	return f.events, nil
+}
+
+func (f *fakeWaitNode) Unsubscribe(context.Context, string, string) error {
+	return nil
+}
+
+func (f *fakeWaitNode) Tx(_ context.Context, hash []byte, _ bool) (*coretypes.ResultTx, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.lookups++
+	if f.txErr != nil {
+		return nil, f.txErr
+	}
+	if f.foundAfter < 0 || f.lookups <= f.foundAfter {
+		return nil, fmt.Errorf("tx (%X) not found", hash)
+	}
+	return &coretypes.ResultTx{Hash: hash, Height: 10, TxResult: abci.ResponseDeliverTx{Code: f.code}}, nil
+}
+
+func (f *fakeWaitNode) Status(context.Context) (*coretypes.ResultStatus, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	height := f.heights[len(f.heights)-1]
+	if f.statuses < len(f.heights) {
+		height = f.heights[f.statuses]
+	}
+	f.statuses++
+	return &coretypes.ResultStatus{SyncInfo: coretypes.SyncInfo{LatestBlockHeight: height}}, nil
+}
+
+func newWaitTestClient(t *testing.T, node *fakeWaitNode) *Client {
+	c := newOfflineTestClient(t)
+	c.cfg = &config.LorenzoConfig{}
+	c.timeout = time.Second
+	var err error
+	c.QueryClient, err = query.NewWithClient(node, time.Second)
+	require.NoError(t, err)
+	return c
+}
+
+func TestWaitForTx(t *testing.T) {
+	hash := "ABCD"
+	hashBytes, err := hex.DecodeString(hash)
+	require.NoError(t, err)
+	included := func() chan coretypes.ResultEvent {
+		events := make(chan coretypes.ResultEvent, 1)
+		events <- coretypes.ResultEvent{Data: types.EventDataTx{TxResult: abci.TxResult{Height: 11, Tx: types.Tx{}}}}
+		return events
+	}
+	closed := func() chan coretypes.ResultEvent {
+		events := make(chan coretypes.ResultEvent)
+		close(events)
+		return events
+	}
+
+	for _, tc := range []struct {
+		name    string
+		node    *fakeWaitNode
+		opts    []WaitOption
+		height  int64
+		lookups int
+		err     error
+	}{
+		{
+			name:    "polled until found",
+			node:    &fakeWaitNode{foundAfter: 2},
+			height:  10,
+			lookups: 3,
+		},
+		{
+			name:    "failed in the block",
+			node:    &fakeWaitNode{code: 5},
+			height:  10,
+			lookups: 1,
+			err:     &TxError{},
+		},
+		{
+			name:    "awaited through the subscription, polled once",
+			node:    &fakeWaitNode{running: true, events: included(), foundAfter: -1},
+			height:  11,
+			lookups: 1,
+		},
+		{
+			name:    "polled after the subscription closed",
+			node:    &fakeWaitNode{running: true, events: closed(), foundAfter: 1},
+			height:  10,
+			lookups: 2,
+		},
+		{
+			name:    "indexing disabled without subscription",
+			node:    &fakeWaitNode{txErr: errors.New("transaction indexing is disabled")},
+			lookups: 1,
+			err:     errors.New("transaction indexing is disabled on rpc url"),
+		},
+		{
+			name: "timed out",
+			node: &fakeWaitNode{foundAfter: -1},
+			opts: []WaitOption{WithWaitTimeout(50 * time.Millisecond)},
+			err:  context.DeadlineExceeded,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			c := newWaitTestClient(t, tc.node)
+			opts := append([]WaitOption{WithPollInterval(time.Millisecond)}, tc.opts...)
+			res, err := c.WaitForTx(context.Background(), hash, opts...)
+
+			switch expected := tc.err.(type) {
+			case nil:
+				require.NoError(t, err)
+			case *TxError:
+				require.ErrorAs(t, err, &expected)
+			default:
+				if errors.Is(expected, context.DeadlineExceeded) {
+					require.ErrorIs(t, err, context.DeadlineExceeded)
+				} else {
+					require.ErrorContains(t, err, expected.Error())
+				}
+			}
+			if tc.height != 0 {
+				require.Equal(t, tc.height, res.Height)
+				require.Equal(t, hashBytes, []byte(res.Hash))
+			}
+			if tc.lookups != 0 {
+				require.Equal(t, tc.lookups, tc.node.lookups)
+			}
+		})
+	}
+
+	_, err = newWaitTestClient(t, &fakeWaitNode{}).WaitForTx(context.Background(), "not hex")
+	require.Error(t, err)
+}
+
+func TestWaitForConfirmations(t *testing.T) {
+	for _, tc := range []struct {
+		name          string
+		heights       []int64
+		confirmations int64
+		expected      int64
+		statuses      int
+	}{
+		{"already confirmed", []int64{15}, 2, 5, 1},
+		{"confirmed by the next blocks", []int64{10, 10, 11, 12}, 2, 2, 4},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			node := &fakeWaitNode{heights: tc.heights}
+			c := newWaitTestClient(t, node)
+			n, err := c.waitForConfirmations(context.Background(), 10, tc.confirmations, time.Millisecond)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, n)
+			require.Equal(t, tc.statuses, node.statuses)
+		})
+	}
+
+	// the wait gives up with its context when no block is built
+	c := newWaitTestClient(t, &fakeWaitNode{heights: []int64{10}})
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := c.waitForConfirmations(ctx, 10, 1, time.Millisecond)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestPollBackoff(t *testing.T) {
+	for _, tc := range []struct {
+		initial   time.Duration
+		intervals []time.Duration
+	}{
+		{time.Second, []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, 15 * time.Second, 15 * time.Second}},
+		{0, []time.Duration{txPollInterval, 2 * txPollInterval}},
+		{time.Minute, []time.Duration{time.Minute, time.Minute}},
+	} {
+		b := newPollBackoff(tc.initial)
+		var intervals []time.Duration
+		for range tc.intervals {
+			intervals = append(intervals, b.next())
+		}
+		require.Equal(t, tc.intervals, intervals, tc.initial)
+	}
+
+	b := newPollBackoff(time.Second)
+	b.next()
+	b.next()
+	b.reset()
+	require.Equal(t, time.Second, b.next())
+}
@@ -0,0 +1,21 @@
+package config
+
+import "fmt"
+
+// Bitcoin networks, as named by the address package, which config does not depend on
+const (
+	btcNetworkMainnet = "mainnet"
+	btcNetworkTestnet = "testnet"
+	btcNetworkSignet  = "signet"
+	btcNetworkRegtest = "regtest"
+)
+
+func validateBTCNetwork(network string) error {
+	switch network {
+	case "", btcNetworkMainnet, btcNetworkTestnet, btcNetworkSignet, btcNetworkRegtest:
+		return nil
+	default:
+		return fmt.Errorf("unknown network %q, expected %q, %q, %q or %q",
+			network, btcNetworkMainnet, btcNetworkTestnet, btcNetworkSignet, btcNetworkRegtest)
+	}
+}
@@ -0,0 +1,27 @@
+package config_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/Lorenzo-Protocol/lorenzo-sdk/v3/address"
+	"github.com/Lorenzo-Protocol/lorenzo-sdk/v3/config"
+)
+
+// TestBTCNetwork ensures that the config accepts the Bitcoin networks of the address package
+func TestBTCNetwork(t *testing.T) {
+	cfg := config.LorenzoConfig{Timeout: time.Second}
+	for _, network := range []string{"", address.BTCMainnet, address.BTCTestnet, address.BTCSignet, address.BTCRegtest} {
+		cfg.BTCNetwork = network
+		require.NoError(t, cfg.Validate(), network)
+		if network != "" {
+			_, err := address.BTCNetParams(network)
+			require.NoError(t, err, network)
+		}
+	}
+
+	cfg.BTCNetwork = "testnet3"
+	require.Error(t, cfg.Validate())
+}
@@ -0,0 +1,31 @@
+package config
+
+import "fmt"
+
+// Endpoint selection strategies, matching the ones of query.EndpointPool
+const (
+	RPCSelectionRoundRobin    = "round-robin"
+	RPCSelectionLowestLatency = "lowest-latency"
+)
+
+func validateRPCSelection(selection string) error {
+	switch selection {
+	case "", RPCSelectionRoundRobin, RPCSelectionLowestLatency:
+		return nil
+	default:
+		return fmt.Errorf("unknown selection %q, expected %q or %q", selection, RPCSelectionRoundRobin, RPCSelectionLowestLatency)
+	}
+}
+
+func rpcEndpoints(rpcAddr string, rpcAddrs []string) []string {
+	var endpoints []string
+	seen := map[string]bool{}
+	for _, addr := range append([]string{rpcAddr}, rpcAddrs...) {
+		if addr == "" || seen[addr] {
+			continue
+		}
+		seen[addr] = true
+		endpoints = append(endpoints, addr)
+	}
+	return endpoints
+}
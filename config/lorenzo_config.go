@@ -2,18 +2,25 @@ package config
 
 import (
 	"fmt"
+	"net"
 	"net/url"
 	"time"
 
 	"github.com/cosmos/relayer/v2/relayer/chains/cosmos"
 )
 
+// SignModeEIP712 is the sign-mode signing txs as EIP-712 typed data with eth_secp256k1 keys, through
+// the legacy EIP-712 extension option of Ethermint. The other sign modes are direct and amino-json.
+const SignModeEIP712 = "eip712"
+
 // LorenzoConfig defines configuration for the Lorenzo client
 // adapted from https://github.com/strangelove-ventures/lens/blob/v0.5.1/client/config.go
 type LorenzoConfig struct {
 	Key            string        `mapstructure:"key" toml:"key"`
 	ChainID        string        `mapstructure:"chain-id" toml:"chain-id"`
 	RPCAddr        string        `mapstructure:"rpc-addr" toml:"rpc-addr"`
+	GRPCAddr       string        `mapstructure:"grpc-addr" toml:"grpc-addr"`
+	GRPCTLS        bool          `mapstructure:"grpc-tls" toml:"grpc-tls"`
 	AccountPrefix  string        `mapstructure:"account-prefix" toml:"account-prefix"`
 	KeyringBackend string        `mapstructure:"keyring-backend" toml:"keyring-backend"`
 	GasAdjustment  float64       `mapstructure:"gas-adjustment" toml:"gas-adjustment"`
@@ -24,12 +31,73 @@ type LorenzoConfig struct {
 	BlockTimeout   time.Duration `mapstructure:"block-timeout" toml:"block-timeout"`
 	OutputFormat   string        `mapstructure:"output-format" toml:"output-format"`
 	SignModeStr    string        `mapstructure:"sign-mode" toml:"sign-mode"`
+
+	// RPCAddrs lists additional RPC endpoints: when more than one endpoint is configured,
+	// reads are spread over the healthy ones and broadcasts stick to one of them until it fails
+	RPCAddrs               []string      `mapstructure:"rpc-addrs" toml:"rpc-addrs"`
+	RPCSelection           string        `mapstructure:"rpc-selection" toml:"rpc-selection"`
+	RPCMaxHeightLag        int64         `mapstructure:"rpc-max-height-lag" toml:"rpc-max-height-lag"`
+	RPCHealthCheckInterval time.Duration `mapstructure:"rpc-health-check-interval" toml:"rpc-health-check-interval"`
+
+	// retry policy of tx submissions, zero values fall back to client.DefaultRetryPolicy
+	RetryAttempts  uint          `mapstructure:"retry-attempts" toml:"retry-attempts"`
+	RetryDelay     time.Duration `mapstructure:"retry-delay" toml:"retry-delay"`
+	RetryMaxDelay  time.Duration `mapstructure:"retry-max-delay" toml:"retry-max-delay"`
+	RetryMaxJitter time.Duration `mapstructure:"retry-max-jitter" toml:"retry-max-jitter"`
+
+	// DryRun validates, signs and simulates txs without ever broadcasting them
+	DryRun bool `mapstructure:"dry-run" toml:"dry-run"`
+
+	// AuthzGranter makes the client execute its txs on behalf of the given account through x/authz
+	AuthzGranter string `mapstructure:"authz-granter" toml:"authz-granter"`
+	// FeeGranter is the fee payer of the txs of the client, through the x/feegrant allowance it gives to the client key
+	FeeGranter string `mapstructure:"fee-granter" toml:"fee-granter"`
+
+	// Keys lists additional keys of the keyring signing txs besides Key,
+	// selected per tx with client.WithSigner or per msg type URL with KeyRoutes
+	Keys      []string          `mapstructure:"keys" toml:"keys"`
+	KeyRoutes map[string]string `mapstructure:"key-routes" toml:"key-routes"`
+
+	// EVMRPCAddr is the address of the Ethereum JSON-RPC of the node, e.g. http://localhost:8545
+	EVMRPCAddr string `mapstructure:"evm-rpc-addr" toml:"evm-rpc-addr"`
+
+	// BTCNetwork is the Bitcoin network the BTC addresses of burn events are validated against,
+	// one of mainnet, testnet, signet and regtest, any of them being accepted if empty
+	BTCNetwork string `mapstructure:"btc-network" toml:"btc-network"`
 }
 
 func (cfg *LorenzoConfig) Validate() error {
 	if _, err := url.Parse(cfg.RPCAddr); err != nil {
 		return fmt.Errorf("rpc-addr is not correctly formatted: %w", err)
 	}
+	for _, addr := range cfg.RPCAddrs {
+		if _, err := url.Parse(addr); err != nil {
+			return fmt.Errorf("rpc-addrs contains an address that is not correctly formatted: %w", err)
+		}
+	}
+	if err := validateRPCSelection(cfg.RPCSelection); err != nil {
+		return fmt.Errorf("rpc-selection is invalid: %w", err)
+	}
+	if cfg.RPCMaxHeightLag < 0 {
+		return fmt.Errorf("rpc-max-height-lag can't be negative")
+	}
+	if cfg.RPCHealthCheckInterval < 0 {
+		return fmt.Errorf("rpc-health-check-interval can't be negative")
+	}
+	if cfg.RetryDelay < 0 || cfg.RetryMaxDelay < 0 || cfg.RetryMaxJitter < 0 {
+		return fmt.Errorf("retry delays can't be negative")
+	}
+	if _, err := url.Parse(cfg.EVMRPCAddr); err != nil {
+		return fmt.Errorf("evm-rpc-addr is not correctly formatted: %w", err)
+	}
+	if err := validateBTCNetwork(cfg.BTCNetwork); err != nil {
+		return fmt.Errorf("btc-network is invalid: %w", err)
+	}
+	if cfg.GRPCAddr != "" {
+		if _, _, err := net.SplitHostPort(cfg.GRPCAddr); err != nil {
+			return fmt.Errorf("grpc-addr is not correctly formatted: %w", err)
+		}
+	}
 	if cfg.Timeout <= 0 {
 		return fmt.Errorf("timeout must be positive")
 	}
@@ -39,11 +107,21 @@ func (cfg *LorenzoConfig) Validate() error {
 	return nil
 }
 
+// RPCEndpoints returns RPCAddr followed by the RPCAddrs, without duplicates
+func (cfg *LorenzoConfig) RPCEndpoints() []string {
+	return rpcEndpoints(cfg.RPCAddr, cfg.RPCAddrs)
+}
+
 func (cfg *LorenzoConfig) ToCosmosProviderConfig() cosmos.CosmosProviderConfig {
+	rpcAddr := cfg.RPCAddr
+	if endpoints := cfg.RPCEndpoints(); rpcAddr == "" && len(endpoints) > 0 {
+		rpcAddr = endpoints[0]
+	}
+
 	return cosmos.CosmosProviderConfig{
 		Key:            cfg.Key,
 		ChainID:        cfg.ChainID,
-		RPCAddr:        cfg.RPCAddr,
+		RPCAddr:        rpcAddr,
 		AccountPrefix:  cfg.AccountPrefix,
 		KeyringBackend: cfg.KeyringBackend,
 		GasAdjustment:  cfg.GasAdjustment,
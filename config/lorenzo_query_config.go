@@ -2,20 +2,50 @@ package config
 
 import (
 	"fmt"
+	"net"
 	"net/url"
 	"time"
 )
 
 // LorenzoConfig defines configuration for the Lorenzo query client
+// GRPCAddr is optional: when set, module queries are sent to the node's gRPC server
+// instead of as ABCI queries over RPCAddr
+// RPCAddrs lists additional RPC endpoints: when more than one endpoint is configured,
+// the client fails over between them (see query.EndpointPool)
 type LorenzoQueryConfig struct {
-	RPCAddr string        `mapstructure:"rpc-addr" toml:"rpc_addr"`
-	Timeout time.Duration `mapstructure:"timeout" toml:"rpc_timeout"`
+	RPCAddr                string        `mapstructure:"rpc-addr" toml:"rpc_addr"`
+	RPCAddrs               []string      `mapstructure:"rpc-addrs" toml:"rpc_addrs"`
+	RPCSelection           string        `mapstructure:"rpc-selection" toml:"rpc_selection"`
+	RPCMaxHeightLag        int64         `mapstructure:"rpc-max-height-lag" toml:"rpc_max_height_lag"`
+	RPCHealthCheckInterval time.Duration `mapstructure:"rpc-health-check-interval" toml:"rpc_health_check_interval"`
+	GRPCAddr               string        `mapstructure:"grpc-addr" toml:"grpc_addr"`
+	GRPCTLS                bool          `mapstructure:"grpc-tls" toml:"grpc_tls"`
+	Timeout                time.Duration `mapstructure:"timeout" toml:"rpc_timeout"`
 }
 
 func (cfg *LorenzoQueryConfig) Validate() error {
 	if _, err := url.Parse(cfg.RPCAddr); err != nil {
 		return fmt.Errorf("cfg.RPCAddr is not correctly formatted: %w", err)
 	}
+	for _, addr := range cfg.RPCAddrs {
+		if _, err := url.Parse(addr); err != nil {
+			return fmt.Errorf("cfg.RPCAddrs contains an address that is not correctly formatted: %w", err)
+		}
+	}
+	if err := validateRPCSelection(cfg.RPCSelection); err != nil {
+		return fmt.Errorf("cfg.RPCSelection is invalid: %w", err)
+	}
+	if cfg.RPCMaxHeightLag < 0 {
+		return fmt.Errorf("cfg.RPCMaxHeightLag can't be negative")
+	}
+	if cfg.RPCHealthCheckInterval < 0 {
+		return fmt.Errorf("cfg.RPCHealthCheckInterval can't be negative")
+	}
+	if cfg.GRPCAddr != "" {
+		if _, _, err := net.SplitHostPort(cfg.GRPCAddr); err != nil {
+			return fmt.Errorf("cfg.GRPCAddr is not correctly formatted: %w", err)
+		}
+	}
 	if cfg.Timeout <= 0 {
 		return fmt.Errorf("cfg.Timeout must be positive")
 	}
@@ -28,3 +58,8 @@ func DefaultLorenzoQueryConfig() LorenzoQueryConfig {
 		Timeout: 20 * time.Second,
 	}
 }
+
+// RPCEndpoints returns RPCAddr followed by the RPCAddrs, without duplicates
+func (cfg *LorenzoQueryConfig) RPCEndpoints() []string {
+	return rpcEndpoints(cfg.RPCAddr, cfg.RPCAddrs)
+}
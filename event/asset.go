@@ -0,0 +1,93 @@
+package event
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	sdkmath "cosmossdk.io/math"
+	btcstakingtypes "github.com/Lorenzo-Protocol/lorenzo/v3/x/btcstaking/types"
+)
+
+// DefaultBTCAsset is the asset of the BTC staking records, whose satoshi amounts are minted
+// as 18 decimals stBTC
+var DefaultBTCAsset = Asset{
+	Denom:          btcstakingtypes.NativeTokenDenom,
+	SourceDecimals: 8,
+	TargetDecimals: 18,
+}
+
+// Asset describes how the amounts of an asset convert between the chain it comes from,
+// e.g. satoshis on Bitcoin, and the token it is minted as on Lorenzo
+type Asset struct {
+	// Denom is the denom of the token on Lorenzo
+	Denom string
+	// SourceDecimals is the precision of the amounts on the source chain
+	SourceDecimals uint32
+	// TargetDecimals is the precision of the token on Lorenzo
+	TargetDecimals uint32
+}
+
+// ErrPrecisionLoss is returned when scaling an amount to a less precise asset would drop some of its digits
+var ErrPrecisionLoss = errors.New("amount would lose precision")
+
+// ToTarget scales an amount of the source chain to the precision of the token, failing with
+// ErrPrecisionLoss if the token is less precise and the amount would lose precision
+func (a Asset) ToTarget(amount sdkmath.Int) (sdkmath.Int, error) {
+	return scaleExact(amount, a.SourceDecimals, a.TargetDecimals)
+}
+
+// ToSource scales an amount of the token to the precision of the source chain, failing with
+// ErrPrecisionLoss if the source chain is less precise and the amount would lose precision
+func (a Asset) ToSource(amount sdkmath.Int) (sdkmath.Int, error) {
+	return scaleExact(amount, a.TargetDecimals, a.SourceDecimals)
+}
+
+// FloorToTarget is like ToTarget but rounds the scaled amount down, also returning the
+// remainder of amount that the token cannot represent
+func (a Asset) FloorToTarget(amount sdkmath.Int) (scaled sdkmath.Int, remainder sdkmath.Int, err error) {
+	return scale(amount, a.SourceDecimals, a.TargetDecimals)
+}
+
+// FloorToSource is like ToSource but rounds the scaled amount down, also returning the
+// remainder of amount that the source chain cannot represent, e.g. the dust below a satoshi
+func (a Asset) FloorToSource(amount sdkmath.Int) (scaled sdkmath.Int, remainder sdkmath.Int, err error) {
+	return scale(amount, a.TargetDecimals, a.SourceDecimals)
+}
+
+// scaleExact is like scale but fails if there is a remainder
+func scaleExact(amount sdkmath.Int, from, to uint32) (sdkmath.Int, error) {
+	scaled, remainder, err := scale(amount, from, to)
+	if err != nil {
+		return sdkmath.Int{}, err
+	}
+	if !remainder.IsZero() {
+		return sdkmath.Int{}, fmt.Errorf("%w: %s scaled from %d to %d decimals", ErrPrecisionLoss, amount, from, to)
+	}
+	return scaled, nil
+}
+
+// scale converts amount from the given decimals to the given ones, rounding down. It returns
+// the remainder of amount that the new decimals cannot represent, in the given decimals, and
+// fails if the amount is negative or overflows.
+func scale(amount sdkmath.Int, from, to uint32) (sdkmath.Int, sdkmath.Int, error) {
+	if amount.IsNil() || amount.IsNegative() {
+		return sdkmath.Int{}, sdkmath.Int{}, fmt.Errorf("invalid amount %s", amount)
+	}
+	if from == to {
+		return amount, sdkmath.ZeroInt(), nil
+	}
+
+	if to > from {
+		factor := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(to-from)), nil)
+		scaled := new(big.Int).Mul(amount.BigInt(), factor)
+		if scaled.BitLen() > sdkmath.MaxBitLen {
+			return sdkmath.Int{}, sdkmath.Int{}, fmt.Errorf("amount %s overflows once scaled from %d to %d decimals", amount, from, to)
+		}
+		return sdkmath.NewIntFromBigInt(scaled), sdkmath.ZeroInt(), nil
+	}
+
+	factor := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(from-to)), nil)
+	scaled, rem := new(big.Int).QuoRem(amount.BigInt(), factor, new(big.Int))
+	return sdkmath.NewIntFromBigInt(scaled), sdkmath.NewIntFromBigInt(rem), nil
+}
@@ -0,0 +1,44 @@
+package event_test
+
+import (
+	"testing"
+
+	sdkmath "cosmossdk.io/math"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Lorenzo-Protocol/lorenzo-sdk/v3/event"
+)
+
+func TestAsset(t *testing.T) {
+	asset := event.DefaultBTCAsset
+
+	scaled, err := asset.ToTarget(sdkmath.NewInt(12345))
+	require.NoError(t, err)
+	require.Equal(t, "123450000000000", scaled.String())
+
+	raw, err := asset.ToSource(scaled)
+	require.NoError(t, err)
+	require.Equal(t, int64(12345), raw.Int64())
+
+	// amounts below a satoshi cannot be released on Bitcoin
+	_, err = asset.ToSource(scaled.AddRaw(1))
+	require.ErrorIs(t, err, event.ErrPrecisionLoss)
+	raw, remainder, err := asset.FloorToSource(scaled.AddRaw(1))
+	require.NoError(t, err)
+	require.Equal(t, int64(12345), raw.Int64())
+	require.Equal(t, int64(1), remainder.Int64())
+	_, err = asset.ToTarget(sdkmath.NewInt(-1))
+	require.Error(t, err)
+
+	// tokens less precise than their source chain only take round amounts
+	asset = event.Asset{Denom: "token", SourceDecimals: 18, TargetDecimals: 6}
+	scaled, err = asset.ToTarget(sdkmath.NewInt(2e12))
+	require.NoError(t, err)
+	require.Equal(t, int64(2), scaled.Int64())
+	_, err = asset.ToTarget(sdkmath.NewInt(2e12 + 1))
+	require.ErrorIs(t, err, event.ErrPrecisionLoss)
+	scaled, remainder, err = asset.FloorToTarget(sdkmath.NewInt(2e12 + 1))
+	require.NoError(t, err)
+	require.Equal(t, int64(2), scaled.Int64())
+	require.Equal(t, int64(1), remainder.Int64())
+}
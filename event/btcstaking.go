@@ -4,19 +4,24 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"math/big"
+	"strconv"
 	"strings"
 
+	sdkmath "cosmossdk.io/math"
 	"github.com/btcsuite/btcd/chaincfg/chainhash"
 	abci_types "github.com/cometbft/cometbft/abci/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	ethereum "github.com/ethereum/go-ethereum/common"
+
+	"github.com/Lorenzo-Protocol/lorenzo-sdk/v3/address"
 )
 
 const (
 	EventTypeMint       = "lorenzo.btcstaking.v1.EventBTCStakingCreated"
 	EventTypeBurn       = "lorenzo.btcstaking.v1.EventBurnCreated"
-	Bech32PrefixAccAddr = "lrz"
+	Bech32PrefixAccAddr = address.AccountPrefix
 )
 
 type (
@@ -24,16 +29,32 @@ type (
 		Amount           sdk.Coin `json:"amount"`
 		BtcTargetAddress string   `json:"btc_target_address"`
 		Signer           string   `json:"signer"`
+		// RawAmount is the burnt amount of the token and ScaledAmount the amount to release on the
+		// source chain
+		RawAmount    sdkmath.Int `json:"raw_amount"`
+		ScaledAmount sdkmath.Int `json:"scaled_amount"`
+		// Remainder is the part of RawAmount below the precision of the source chain, e.g. the dust
+		// below a satoshi, which cannot be released. It is only set by ParseBurnEventFloor.
+		Remainder sdkmath.Int `json:"remainder"`
+		// BtcTarget is the decoded BtcTargetAddress, with its type and scriptPubKey
+		BtcTarget *address.BTCAddress `json:"btc_target"`
 	}
 
 	MintEvent struct {
-		TxHash       string  `json:"tx_hash"`
+		TxHash string `json:"tx_hash"`
+		// Amount is the minted amount of the token.
+		// Deprecated: use ScaledAmount
 		Amount       big.Int `json:"amount"`
 		ReceiverAddr string  `json:"receiver_addr"`
 		AgentName    string  `json:"agent_name"`
 		AgentBtcAddr string  `json:"agent_btc_addr"`
 		ChainId      *uint32 `json:"chain_id"`
 		PlanId       *uint32 `json:"plan_id"`
+		// Denom is the denom of the minted token, RawAmount the staked amount on the source
+		// chain and ScaledAmount the minted amount of the token
+		Denom        string      `json:"denom"`
+		RawAmount    sdkmath.Int `json:"raw_amount"`
+		ScaledAmount sdkmath.Int `json:"scaled_amount"`
 	}
 
 	MintRecordValue struct {
@@ -47,7 +68,40 @@ type (
 	}
 )
 
+// UnmarshalJSON accepts the plan id as a number or as a string, the latter being how the
+// proto JSON of the typed events encodes the uint64 plan id of the records
+func (v *MintRecordValue) UnmarshalJSON(bz []byte) error {
+	type mintRecordValue MintRecordValue
+	record := struct {
+		*mintRecordValue
+		PlanId json.RawMessage `json:"plan_id"`
+	}{mintRecordValue: (*mintRecordValue)(v)}
+	if err := json.Unmarshal(bz, &record); err != nil {
+		return err
+	}
+
+	v.PlanId = nil
+	planID := strings.Trim(string(record.PlanId), "\"")
+	if planID == "" || planID == "null" {
+		return nil
+	}
+	id, err := strconv.ParseUint(planID, 10, 32)
+	if err != nil {
+		return fmt.Errorf("invalid plan_id: %w", err)
+	}
+	v.PlanId = new(uint32)
+	*v.PlanId = uint32(id)
+	return nil
+}
+
+// NewMintEvent parses a mint event of the BTC staking records, see DefaultBTCAsset
 func NewMintEvent(event abci_types.Event) (*MintEvent, error) {
+	return NewMintEventWithAsset(event, DefaultBTCAsset)
+}
+
+// NewMintEventWithAsset parses a mint event whose amount is scaled from the source chain
+// to the token as described by asset, rejecting amounts that would lose precision
+func NewMintEventWithAsset(event abci_types.Event, asset Asset) (*MintEvent, error) {
 	var record string
 	for _, attr := range event.Attributes {
 		if attr.Key == "record" {
@@ -75,61 +129,131 @@ func NewMintEvent(event abci_types.Event) (*MintEvent, error) {
 		return nil, err
 	}
 
-	amount, ok := new(big.Int).SetString(value.Amount, 10)
+	amount, ok := sdkmath.NewIntFromString(value.Amount)
 	if !ok {
 		return nil, errors.New("parse mint event error: invalid amount")
 	}
+	scaledAmount, err := asset.ToTarget(amount)
+	if err != nil {
+		return nil, fmt.Errorf("parse mint event error: %w", err)
+	}
 
 	receiverAddrBytes, err := base64.StdEncoding.DecodeString(value.ReceiverAddr)
 	if err != nil {
 		return nil, err
 	}
-	receiverAddr := ethereum.BytesToAddress(receiverAddrBytes)
+	// a record without receiver keeps the zero address
+	receiverAddr := ethereum.BytesToAddress(receiverAddrBytes).Hex()
+	if len(receiverAddrBytes) != 0 {
+		if receiverAddr, err = address.ToHex(receiverAddrBytes); err != nil {
+			return nil, fmt.Errorf("parse mint event error: invalid receiver address: %w", err)
+		}
+	}
 
 	return &MintEvent{
 		TxHash:       txHash.String(),
-		Amount:       *new(big.Int).Mul(amount, big.NewInt(1e10)),
-		ReceiverAddr: receiverAddr.String(),
+		Amount:       *scaledAmount.BigInt(),
+		ReceiverAddr: receiverAddr,
 		AgentName:    value.AgentName,
 		AgentBtcAddr: value.AgentBtcAddr,
 		ChainId:      value.ChainId,
 		PlanId:       value.PlanId,
+		Denom:        asset.Denom,
+		RawAmount:    amount,
+		ScaledAmount: scaledAmount,
 	}, nil
 }
 
+// NewBurnEvent parses a burn event of stBTC, see DefaultBTCAsset
 func NewBurnEvent(event abci_types.Event) (*BurnEvent, error) {
+	return NewBurnEventWithAsset(event, DefaultBTCAsset)
+}
+
+// NewBurnEventWithAsset parses a burn event whose amount is scaled from the token to the
+// source chain as described by asset, rejecting burns of another denom than the one of asset
+// and amounts that would lose precision, e.g. below a satoshi.
+// The BTC target address may be of any Bitcoin network, see ParseBurnEvent.
+func NewBurnEventWithAsset(event abci_types.Event, asset Asset) (*BurnEvent, error) {
+	return ParseBurnEvent(event, asset, "")
+}
+
+// ParseBurnEvent is like NewBurnEventWithAsset but also requires the BTC target address to be
+// a P2PKH, P2SH, P2WPKH, P2WSH or P2TR address of the given Bitcoin network, or of any of them
+// if btcNetwork is empty. Events missing their amount, signer or target are rejected.
+func ParseBurnEvent(event abci_types.Event, asset Asset, btcNetwork string) (*BurnEvent, error) {
+	return parseBurnEvent(event, asset, btcNetwork, false)
+}
+
+// ParseBurnEventFloor is like ParseBurnEvent but rounds amounts the source chain cannot
+// represent down instead of rejecting them, leaving the dust in BurnEvent.Remainder for the
+// caller to handle
+func ParseBurnEventFloor(event abci_types.Event, asset Asset, btcNetwork string) (*BurnEvent, error) {
+	return parseBurnEvent(event, asset, btcNetwork, true)
+}
+
+func parseBurnEvent(event abci_types.Event, asset Asset, btcNetwork string, floor bool) (*BurnEvent, error) {
 	var (
-		amount           sdk.Coin
-		btcTargetAddress string
-		signer           string
-		err              error
+		amount           *sdk.Coin
+		btcTargetAddress *string
+		signer           *string
 	)
 	for _, attr := range event.Attributes {
-		if attr.Key == "amount" {
-			err = json.Unmarshal([]byte(attr.Value), &amount)
-			if err != nil {
-				return nil, err
-			}
-		}
-
 		value := strings.Trim(attr.Value, "\"")
 
-		if attr.Key == "btc_target_address" {
-			btcTargetAddress = value
-		}
-
-		if attr.Key == "signer" {
-			address, err := sdk.GetFromBech32(value, Bech32PrefixAccAddr)
+		switch attr.Key {
+		case "amount":
+			amount = new(sdk.Coin)
+			if err := json.Unmarshal([]byte(attr.Value), amount); err != nil {
+				return nil, fmt.Errorf("parse burn event error: invalid amount: %w", err)
+			}
+		case "btc_target_address":
+			btcTargetAddress = &value
+		case "signer":
+			hex, err := address.Bech32ToHex(value)
 			if err != nil {
-				return nil, err
+				return nil, fmt.Errorf("parse burn event error: invalid signer: %w", err)
 			}
-			signer = ethereum.BytesToAddress(address).String()
+			signer = &hex
 		}
 	}
+	if amount == nil {
+		return nil, errors.New("parse burn event error: missing amount")
+	}
+	if signer == nil {
+		return nil, errors.New("parse burn event error: missing signer")
+	}
+	if btcTargetAddress == nil {
+		return nil, errors.New("parse burn event error: missing btc_target_address")
+	}
+
+	btcTarget, err := address.ParseBTC(*btcTargetAddress, btcNetwork)
+	if err != nil {
+		return nil, fmt.Errorf("parse burn event error: %w", err)
+	}
+
+	if asset.Denom != "" && amount.Denom != asset.Denom {
+		return nil, fmt.Errorf("parse burn event error: burnt %s instead of %s", amount.Denom, asset.Denom)
+	}
+	var (
+		scaledAmount sdkmath.Int
+		remainder    = sdkmath.ZeroInt()
+	)
+	if floor {
+		scaledAmount, remainder, err = asset.FloorToSource(amount.Amount)
+	} else {
+		scaledAmount, err = asset.ToSource(amount.Amount)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parse burn event error: %w", err)
+	}
 
 	return &BurnEvent{
-		Amount:           amount,
-		BtcTargetAddress: btcTargetAddress,
-		Signer:           signer,
+		Amount:           *amount,
+		BtcTargetAddress: btcTarget.Address,
+		Signer:           *signer,
+		RawAmount:        amount.Amount,
+		ScaledAmount:     scaledAmount,
+		Remainder:        remainder,
+		BtcTarget:        btcTarget,
 	}, nil
 }
@@ -0,0 +1,59 @@
+package event_test
+
+import (
+	"testing"
+
+	btcstakingtypes "github.com/Lorenzo-Protocol/lorenzo/v3/x/btcstaking/types"
+	abci_types "github.com/cometbft/cometbft/abci/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Lorenzo-Protocol/lorenzo-sdk/v3/address"
+	"github.com/Lorenzo-Protocol/lorenzo-sdk/v3/event"
+)
+
+func TestParseBurnEvent(t *testing.T) {
+	burn, err := sdk.TypedEventToEvent(&btcstakingtypes.EventBurnCreated{
+		Signer:           address.ToBech32(make([]byte, 20)),
+		BtcTargetAddress: "bc1p0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7vqzk5jj0",
+		Amount:           sdk.NewInt64Coin("stBTC", 3e10),
+	})
+	require.NoError(t, err)
+
+	parsed, err := event.ParseBurnEvent(abci_types.Event(burn), event.DefaultBTCAsset, address.BTCMainnet)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), parsed.ScaledAmount.Int64())
+	require.True(t, parsed.Remainder.IsZero())
+	require.Equal(t, address.P2TR, parsed.BtcTarget.Type)
+	require.Len(t, parsed.BtcTarget.ScriptPubKey, 34)
+
+	// amounts below a satoshi cannot be released, unless rounded down on request
+	dust, err := sdk.TypedEventToEvent(&btcstakingtypes.EventBurnCreated{
+		Signer:           address.ToBech32(make([]byte, 20)),
+		BtcTargetAddress: "bc1p0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7vqzk5jj0",
+		Amount:           sdk.NewInt64Coin("stBTC", 3e10+1),
+	})
+	require.NoError(t, err)
+	_, err = event.NewBurnEvent(abci_types.Event(dust))
+	require.ErrorIs(t, err, event.ErrPrecisionLoss)
+	_, err = event.ParseBurnEvent(abci_types.Event(dust), event.DefaultBTCAsset, address.BTCMainnet)
+	require.ErrorIs(t, err, event.ErrPrecisionLoss)
+	parsed, err = event.ParseBurnEventFloor(abci_types.Event(dust), event.DefaultBTCAsset, address.BTCMainnet)
+	require.NoError(t, err)
+	require.Equal(t, int64(3e10+1), parsed.RawAmount.Int64())
+	require.Equal(t, int64(3), parsed.ScaledAmount.Int64())
+	require.Equal(t, int64(1), parsed.Remainder.Int64())
+
+	// the target must belong to the network
+	_, err = event.ParseBurnEvent(abci_types.Event(burn), event.DefaultBTCAsset, address.BTCTestnet)
+	require.ErrorIs(t, err, address.ErrInvalidBTCAddress)
+
+	// every attribute is required
+	for i := range burn.Attributes {
+		missing := abci_types.Event{Type: burn.Type}
+		missing.Attributes = append(missing.Attributes, burn.Attributes[:i]...)
+		missing.Attributes = append(missing.Attributes, burn.Attributes[i+1:]...)
+		_, err := event.NewBurnEvent(missing)
+		require.Error(t, err, burn.Attributes[i].Key)
+	}
+}
@@ -0,0 +1,128 @@
+package event
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Checkpoint is the position of the next event a Scanner has to handle, all the events
+// before it having been handled
+type Checkpoint struct {
+	Height     int64 `json:"height"`
+	EventIndex int   `json:"event_index"`
+}
+
+// CheckpointStore persists the checkpoint of a Scanner
+type CheckpointStore interface {
+	// Load returns the saved checkpoint, or nil if none was saved yet
+	Load() (*Checkpoint, error)
+	// Save durably saves the checkpoint, replacing the previous one
+	Save(checkpoint Checkpoint) error
+}
+
+var _ CheckpointStore = &FileCheckpointStore{}
+
+// FileCheckpointStore saves the checkpoint as JSON in a local file, which is replaced
+// atomically so that a crash never leaves a partially written checkpoint
+type FileCheckpointStore struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewFileCheckpointStore returns a store saving the checkpoint in the file at the given path,
+// creating its directory if needed
+func NewFileCheckpointStore(path string) (*FileCheckpointStore, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create the checkpoint directory: %w", err)
+	}
+	return &FileCheckpointStore{path: path}, nil
+}
+
+func (s *FileCheckpointStore) Load() (*Checkpoint, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	bz, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var checkpoint Checkpoint
+	if err := json.Unmarshal(bz, &checkpoint); err != nil {
+		return nil, fmt.Errorf("invalid checkpoint file %s: %w", s.path, err)
+	}
+	return &checkpoint, nil
+}
+
+func (s *FileCheckpointStore) Save(checkpoint Checkpoint) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	bz, err := json.Marshal(checkpoint)
+	if err != nil {
+		return err
+	}
+
+	// write a temporary file next to the checkpoint and rename it over the checkpoint
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(bz); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return err
+	}
+
+	// persist the rename itself
+	dir, err := os.Open(filepath.Dir(s.path))
+	if err != nil {
+		return err
+	}
+	defer dir.Close()
+	return dir.Sync()
+}
+
+// MemoryCheckpointStore keeps the checkpoint in memory, which suits tests and scans that
+// do not need to survive a restart
+type MemoryCheckpointStore struct {
+	mu         sync.Mutex
+	checkpoint *Checkpoint
+}
+
+func (s *MemoryCheckpointStore) Load() (*Checkpoint, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.checkpoint == nil {
+		return nil, nil
+	}
+	checkpoint := *s.checkpoint
+	return &checkpoint, nil
+}
+
+func (s *MemoryCheckpointStore) Save(checkpoint Checkpoint) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.checkpoint = &checkpoint
+	return nil
+}
@@ -0,0 +1,174 @@
+package event
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	agenttypes "github.com/Lorenzo-Protocol/lorenzo/v3/x/agent/types"
+	btclightclienttypes "github.com/Lorenzo-Protocol/lorenzo/v3/x/btclightclient/types"
+	btcstakingtypes "github.com/Lorenzo-Protocol/lorenzo/v3/x/btcstaking/types"
+	plantypes "github.com/Lorenzo-Protocol/lorenzo/v3/x/plan/types"
+	tokentypes "github.com/Lorenzo-Protocol/lorenzo/v3/x/token/types"
+	abci_types "github.com/cometbft/cometbft/abci/types"
+	"github.com/cosmos/gogoproto/jsonpb"
+	"github.com/cosmos/gogoproto/proto"
+)
+
+// DecodeFunc turns an event of a given type into its typed value
+type DecodeFunc func(event abci_types.Event) (interface{}, error)
+
+// Event is an event of a Lorenzo tx or block along with its typed value
+type Event struct {
+	abci_types.Event
+	// Value is the typed event, or nil if the decoder does not know the type of the event.
+	// Typed events are proto messages, the attribute events of x/token are decoded into the
+	// proto events of the module, and the ones of x/plan into the structs of this package.
+	Value interface{}
+}
+
+// Known tells whether the decoder knew the type of the event
+func (e *Event) Known() bool {
+	return e.Value != nil
+}
+
+// Attributes returns the raw attributes of the event by key, the last one winning on duplicates
+func (e *Event) Attributes() map[string]string {
+	return attributes(e.Event)
+}
+
+// Decoder decodes the events of Lorenzo txs and blocks out of a registry of event types.
+// A Decoder is safe for concurrent use.
+type Decoder struct {
+	unmarshaler *jsonpb.Unmarshaler
+
+	mu       sync.RWMutex
+	decoders map[string]DecodeFunc
+}
+
+// NewDecoder returns a decoder of all the events of the Lorenzo modules, the typed ones being
+// unmarshalled with the given interface registry of the app, e.g. the one of lorenzo.MakeEncodingConfig.
+// The BNB light client emits no events, its headers are only known through its queries.
+func NewDecoder(resolver jsonpb.AnyResolver) *Decoder {
+	d := &Decoder{
+		unmarshaler: &jsonpb.Unmarshaler{AllowUnknownFields: true, AnyResolver: resolver},
+		decoders:    map[string]DecodeFunc{},
+	}
+
+	d.RegisterTyped(
+		// x/agent
+		&agenttypes.EventAddAgent{},
+		&agenttypes.EventEditAgent{},
+		&agenttypes.EventRemoveAgent{},
+		// x/btcstaking
+		&btcstakingtypes.EventBTCStakingCreated{},
+		&btcstakingtypes.EventBTCBStakingCreated{},
+		&btcstakingtypes.EventBurnCreated{},
+		// x/btclightclient
+		&btclightclienttypes.EventBTCHeaderInserted{},
+		&btclightclienttypes.EventBTCRollBack{},
+		&btclightclienttypes.EventBTCRollForward{},
+		&btclightclienttypes.EventBTCFeeRateUpdated{},
+	)
+
+	// x/token
+	d.Register(tokentypes.EventTypeRegisterCoin, decodeRegisterPair)
+	d.Register(tokentypes.EventTypeRegisterERC20, decodeRegisterPair)
+	d.Register(tokentypes.EventTypeToggleTokenConversion, decodeToggleTokenConversion)
+	d.Register(tokentypes.EventTypeConvertCoin, decodeConvertCoin)
+	d.Register(tokentypes.EventTypeConvertERC20, decodeConvertERC20)
+
+	// x/plan
+	d.Register(plantypes.EventTypeCreatePlan, decodePlanCreated)
+	d.Register(plantypes.EventTypeUpgradePlan, decodePlanUpgraded)
+	d.Register(plantypes.EventTypeUpdatePlanStatus, decodePlanStatusUpdated)
+	d.Register(plantypes.EventTypeSetMerkleRoot, decodeMerkleRootSet)
+	d.Register(plantypes.EventClaims, decodeClaims)
+	d.Register(plantypes.EventCreateYAT, decodeYATCreated)
+	d.Register(plantypes.EventTypeMintYAT, decodeYATMinted)
+	d.Register(plantypes.EventTypeSetMinter, decodeMinter)
+	d.Register(plantypes.EventTypeRemoveMinter, decodeMinter)
+	d.Register(plantypes.EventSetParams, decodePlanParamsSet)
+
+	return d
+}
+
+// Register decodes the events of the given type with decode, replacing the decoder of the type if any
+func (d *Decoder) Register(eventType string, decode DecodeFunc) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.decoders[eventType] = decode
+}
+
+// RegisterTyped decodes the typed events emitted with EmitTypedEvent into msgs, the type
+// of an event being the full name of its proto message
+func (d *Decoder) RegisterTyped(msgs ...proto.Message) {
+	for _, msg := range msgs {
+		msgType := reflect.TypeOf(msg).Elem()
+		d.Register(proto.MessageName(msg), func(event abci_types.Event) (interface{}, error) {
+			value := reflect.New(msgType).Interface().(proto.Message)
+			if err := d.unmarshalTyped(event, value); err != nil {
+				return nil, err
+			}
+			return value, nil
+		})
+	}
+}
+
+// Decode returns event along with its typed value. An event of an unknown type is returned
+// with a nil value and its raw attributes only.
+func (d *Decoder) Decode(event abci_types.Event) (*Event, error) {
+	d.mu.RLock()
+	decode, ok := d.decoders[event.Type]
+	d.mu.RUnlock()
+
+	decoded := &Event{Event: event}
+	if !ok {
+		return decoded, nil
+	}
+
+	value, err := decode(event)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s event: %w", event.Type, err)
+	}
+	decoded.Value = value
+	return decoded, nil
+}
+
+// DecodeAll decodes events, keeping their order
+func (d *Decoder) DecodeAll(events []abci_types.Event) ([]*Event, error) {
+	decoded := make([]*Event, 0, len(events))
+	for _, event := range events {
+		e, err := d.Decode(event)
+		if err != nil {
+			return nil, err
+		}
+		decoded = append(decoded, e)
+	}
+	return decoded, nil
+}
+
+// unmarshalTyped unmarshals the JSON attributes of a typed event into msg, as sdk.ParseTypedEvent does
+func (d *Decoder) unmarshalTyped(event abci_types.Event, msg proto.Message) error {
+	attrs := make(map[string]json.RawMessage, len(event.Attributes))
+	for _, attr := range event.Attributes {
+		attrs[attr.Key] = json.RawMessage(attr.Value)
+	}
+	bz, err := json.Marshal(attrs)
+	if err != nil {
+		return err
+	}
+	return d.unmarshaler.Unmarshal(strings.NewReader(string(bz)), msg)
+}
+
+// attributes returns the attributes of event by key, the last one winning on duplicates
+func attributes(event abci_types.Event) map[string]string {
+	attrs := make(map[string]string, len(event.Attributes))
+	for _, attr := range event.Attributes {
+		attrs[attr.Key] = attr.Value
+	}
+	return attrs
+}
@@ -0,0 +1,66 @@
+package event_test
+
+import (
+	"testing"
+
+	lorenzo "github.com/Lorenzo-Protocol/lorenzo/v3/app"
+	agenttypes "github.com/Lorenzo-Protocol/lorenzo/v3/x/agent/types"
+	plantypes "github.com/Lorenzo-Protocol/lorenzo/v3/x/plan/types"
+	tokentypes "github.com/Lorenzo-Protocol/lorenzo/v3/x/token/types"
+	abci_types "github.com/cometbft/cometbft/abci/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Lorenzo-Protocol/lorenzo-sdk/v3/event"
+)
+
+func TestDecoder(t *testing.T) {
+	decoder := event.NewDecoder(lorenzo.MakeEncodingConfig().InterfaceRegistry)
+
+	// typed events are decoded into their proto messages
+	addAgent := &agenttypes.EventAddAgent{Id: 3, Name: "agent", BtcReceivingAddress: "tb1qxyz", Sender: "lrz1sender"}
+	typed, err := sdk.TypedEventToEvent(addAgent)
+	require.NoError(t, err)
+
+	decoded, err := decoder.Decode(abci_types.Event(typed))
+	require.NoError(t, err)
+	require.True(t, decoded.Known())
+	require.Equal(t, addAgent, decoded.Value)
+
+	// attribute events of x/token and x/plan are decoded too
+	decoded, err = decoder.Decode(abci_types.Event(sdk.NewEvent(tokentypes.EventTypeConvertCoin,
+		sdk.NewAttribute(sdk.AttributeKeySender, "lrz1sender"),
+		sdk.NewAttribute(tokentypes.AttributeKeyReceiver, "0x01"),
+		sdk.NewAttribute(sdk.AttributeKeyAmount, "100"),
+		sdk.NewAttribute(tokentypes.AttributeKeyCosmosCoin, "stBTC"),
+		sdk.NewAttribute(tokentypes.AttributeKeyERC20Token, "0x02"),
+	)))
+	require.NoError(t, err)
+	require.Equal(t, &tokentypes.EventConvertCoin{
+		Sender: "lrz1sender", Receiver: "0x01", Amount: "100", Denom: "stBTC", ContractAddress: "0x02",
+	}, decoded.Value)
+
+	decoded, err = decoder.Decode(abci_types.Event(sdk.NewEvent(plantypes.EventTypeUpdatePlanStatus,
+		sdk.NewAttribute(plantypes.AttributeKeySender, "lrz1sender"),
+		sdk.NewAttribute(plantypes.AttributeKeyUpdatePlanStatusPlanId, "7"),
+		sdk.NewAttribute(plantypes.AttributeKeyUpdatePlanStatusOldStatus, plantypes.PlanStatus_Unpause.String()),
+		sdk.NewAttribute(plantypes.AttributeKeyUpdatePlanStatusNewStatus, plantypes.PlanStatus_Pause.String()),
+	)))
+	require.NoError(t, err)
+	require.Equal(t, &event.PlanStatusUpdated{
+		Sender: "lrz1sender", PlanId: 7, OldStatus: plantypes.PlanStatus_Unpause, NewStatus: plantypes.PlanStatus_Pause,
+	}, decoded.Value)
+
+	_, err = decoder.Decode(abci_types.Event(sdk.NewEvent(plantypes.EventTypeSetMerkleRoot,
+		sdk.NewAttribute(plantypes.AttributeKeySetMerkleRootPlanId, "not a number"),
+	)))
+	require.Error(t, err)
+
+	// unknown events only come with their raw attributes
+	decoded, err = decoder.Decode(abci_types.Event(sdk.NewEvent(sdk.EventTypeMessage,
+		sdk.NewAttribute(sdk.AttributeKeyModule, "bank"),
+	)))
+	require.NoError(t, err)
+	require.False(t, decoded.Known())
+	require.Equal(t, map[string]string{sdk.AttributeKeyModule: "bank"}, decoded.Attributes())
+}
@@ -0,0 +1,230 @@
+package event
+
+import (
+	"fmt"
+	"strconv"
+
+	plantypes "github.com/Lorenzo-Protocol/lorenzo/v3/x/plan/types"
+	abci_types "github.com/cometbft/cometbft/abci/types"
+)
+
+// x/plan emits attribute events without proto counterparts, which are decoded into the structs below
+
+type (
+	// PlanCreated is the create_plan event
+	PlanCreated struct {
+		Sender string
+		Plan   plantypes.Plan
+	}
+
+	// PlanUpgraded is the upgrade_plan event
+	PlanUpgraded struct {
+		Sender            string
+		OldImplementation string
+		NewImplementation string
+	}
+
+	// PlanStatusUpdated is the update_plan_status event
+	PlanStatusUpdated struct {
+		Sender    string
+		PlanId    uint64
+		OldStatus plantypes.PlanStatus
+		NewStatus plantypes.PlanStatus
+	}
+
+	// MerkleRootSet is the set_merkle_root event
+	MerkleRootSet struct {
+		Sender     string
+		PlanId     uint64
+		MerkleRoot string
+	}
+
+	// Claims is the claims event
+	Claims struct {
+		Sender      string
+		PlanId      uint64
+		Receiver    string
+		RoundId     string
+		Amount      string
+		MerkleProof string
+	}
+
+	// YATCreated is the create_yat event
+	YATCreated struct {
+		Sender          string
+		ContractAddress string
+		Name            string
+		Symbol          string
+	}
+
+	// YATMinted is the mint_yat event
+	YATMinted struct {
+		PlanId  uint64
+		Account string
+		Amount  string
+	}
+
+	// Minter is the set_minter or remove_minter event
+	Minter struct {
+		Sender   string
+		Minter   string
+		Contract string
+	}
+
+	// PlanParamsSet is the set_params event emitted when the plan contracts are deployed
+	PlanParamsSet struct {
+		BeaconAddr string
+		LogicAddr  string
+	}
+)
+
+func decodePlanCreated(event abci_types.Event) (interface{}, error) {
+	attrs := attributes(event)
+	id, err := parseUint(attrs, plantypes.AttributeKeyCreatePlanId)
+	if err != nil {
+		return nil, err
+	}
+	agentID, err := parseUint(attrs, plantypes.AttributeKeyCreatePlanAgentId)
+	if err != nil {
+		return nil, err
+	}
+	startTime, err := parseUint(attrs, plantypes.AttributeKeyCreatePlanPlanStartTime)
+	if err != nil {
+		return nil, err
+	}
+	periodTime, err := parseUint(attrs, plantypes.AttributeKeyCreatePlanPeriodTime)
+	if err != nil {
+		return nil, err
+	}
+	return &PlanCreated{
+		Sender: attrs[plantypes.AttributeKeySender],
+		Plan: plantypes.Plan{
+			Id:                 id,
+			Name:               attrs[plantypes.AttributeKeyCreatePlanName],
+			PlanDescUri:        attrs[plantypes.AttributeKeyCreatePlanDescUri],
+			AgentId:            agentID,
+			PlanStartTime:      startTime,
+			PeriodTime:         periodTime,
+			YatContractAddress: attrs[plantypes.AttributeKeyCreatePlanYatContractAddress],
+			ContractAddress:    attrs[plantypes.AttributeKeyCreatePlanContractAddress],
+		},
+	}, nil
+}
+
+func decodePlanUpgraded(event abci_types.Event) (interface{}, error) {
+	attrs := attributes(event)
+	return &PlanUpgraded{
+		Sender:            attrs[plantypes.AttributeKeySender],
+		OldImplementation: attrs[plantypes.AttributeKeyUpgradePlanOldImplementation],
+		NewImplementation: attrs[plantypes.AttributeKeyUpgradePlanNewImplementation],
+	}, nil
+}
+
+func decodePlanStatusUpdated(event abci_types.Event) (interface{}, error) {
+	attrs := attributes(event)
+	planID, err := parseUint(attrs, plantypes.AttributeKeyUpdatePlanStatusPlanId)
+	if err != nil {
+		return nil, err
+	}
+	oldStatus, err := parsePlanStatus(attrs, plantypes.AttributeKeyUpdatePlanStatusOldStatus)
+	if err != nil {
+		return nil, err
+	}
+	newStatus, err := parsePlanStatus(attrs, plantypes.AttributeKeyUpdatePlanStatusNewStatus)
+	if err != nil {
+		return nil, err
+	}
+	return &PlanStatusUpdated{
+		Sender:    attrs[plantypes.AttributeKeySender],
+		PlanId:    planID,
+		OldStatus: oldStatus,
+		NewStatus: newStatus,
+	}, nil
+}
+
+func decodeMerkleRootSet(event abci_types.Event) (interface{}, error) {
+	attrs := attributes(event)
+	planID, err := parseUint(attrs, plantypes.AttributeKeySetMerkleRootPlanId)
+	if err != nil {
+		return nil, err
+	}
+	return &MerkleRootSet{
+		Sender:     attrs[plantypes.AttributeKeySender],
+		PlanId:     planID,
+		MerkleRoot: attrs[plantypes.AttributeKeySetMerkleRootMerkleRoot],
+	}, nil
+}
+
+func decodeClaims(event abci_types.Event) (interface{}, error) {
+	attrs := attributes(event)
+	planID, err := parseUint(attrs, plantypes.AttributeKeyClaimsPlanId)
+	if err != nil {
+		return nil, err
+	}
+	return &Claims{
+		Sender:      attrs[plantypes.AttributeKeySender],
+		PlanId:      planID,
+		Receiver:    attrs[plantypes.AttributeKeyClaimsReceiver],
+		RoundId:     attrs[plantypes.AttributeKeyClaimsRoundId],
+		Amount:      attrs[plantypes.AttributeKeyClaimsAmount],
+		MerkleProof: attrs[plantypes.AttributeKeyClaimsMerkleProof],
+	}, nil
+}
+
+func decodeYATCreated(event abci_types.Event) (interface{}, error) {
+	attrs := attributes(event)
+	return &YATCreated{
+		Sender:          attrs[plantypes.AttributeKeySender],
+		ContractAddress: attrs[plantypes.AttributeKeyCreateYATContractAddress],
+		Name:            attrs[plantypes.AttributeKeyCreateYATName],
+		Symbol:          attrs[plantypes.AttributeKeyCreateYATSymbol],
+	}, nil
+}
+
+func decodeYATMinted(event abci_types.Event) (interface{}, error) {
+	attrs := attributes(event)
+	planID, err := parseUint(attrs, plantypes.AttributeKeyPlanId)
+	if err != nil {
+		return nil, err
+	}
+	return &YATMinted{
+		PlanId:  planID,
+		Account: attrs[plantypes.AttributeKeyAccount],
+		Amount:  attrs[plantypes.AttributeKeyAmount],
+	}, nil
+}
+
+func decodeMinter(event abci_types.Event) (interface{}, error) {
+	attrs := attributes(event)
+	return &Minter{
+		Sender:   attrs[plantypes.AttributeKeySender],
+		Minter:   attrs[plantypes.AttributeKeyMinter],
+		Contract: attrs[plantypes.AttributeKeyContract],
+	}, nil
+}
+
+func decodePlanParamsSet(event abci_types.Event) (interface{}, error) {
+	attrs := attributes(event)
+	return &PlanParamsSet{
+		BeaconAddr: attrs[plantypes.AttributeKeyBeaconAddr],
+		LogicAddr:  attrs[plantypes.AttributeKeyLogicAddr],
+	}, nil
+}
+
+// parseUint parses the attribute with the given key as a uint64
+func parseUint(attrs map[string]string, key string) (uint64, error) {
+	value, err := strconv.ParseUint(attrs[key], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s attribute: %w", key, err)
+	}
+	return value, nil
+}
+
+// parsePlanStatus parses the attribute with the given key as a plan status
+func parsePlanStatus(attrs map[string]string, key string) (plantypes.PlanStatus, error) {
+	status, ok := plantypes.PlanStatus_value[attrs[key]]
+	if !ok {
+		return 0, fmt.Errorf("invalid %s attribute: unknown plan status %q", key, attrs[key])
+	}
+	return plantypes.PlanStatus(status), nil
+}
@@ -0,0 +1,125 @@
+package event
+
+import (
+	"context"
+	"fmt"
+
+	rpcclient "github.com/cometbft/cometbft/rpc/client"
+)
+
+// ScannedEvent is a mint or burn event found by a Scanner, along with its position in the chain
+type ScannedEvent struct {
+	Height int64
+	// TxHash is the hash of the Lorenzo tx that emitted the event, as upper case hex
+	TxHash string
+	// EventIndex is the index of the event among all the events of the block
+	EventIndex int
+	// Mint is set for mint events, Burn for burn events
+	Mint *MintEvent
+	Burn *BurnEvent
+}
+
+// ScannerOptions configures a Scanner
+type ScannerOptions struct {
+	// StreamOptions configures how the scanner follows the chain, its EventTypes being ignored
+	StreamOptions
+	// StartHeight is the height the scan starts at as long as no checkpoint was saved,
+	// the scan starting at the next block if 0
+	StartHeight int64
+	// Asset scales the amounts of the events, DefaultBTCAsset if zero
+	Asset Asset
+	// BTCNetwork is the Bitcoin network the BTC target addresses of burns must belong to,
+	// any network being accepted if empty
+	BTCNetwork string
+}
+
+// Scanner walks the blocks of the chain from a persisted checkpoint and hands every mint and
+// burn event over to a handler. The checkpoint is saved after every handled event and every
+// block, so a scan resumes right after the last event the handler succeeded on.
+type Scanner struct {
+	stream      *Stream
+	store       CheckpointStore
+	startHeight int64
+	asset       Asset
+	btcNetwork  string
+}
+
+// NewScanner creates a Scanner reading blocks through the given RPC client and saving its
+// checkpoint in the given store
+func NewScanner(rpcClient rpcclient.Client, decoder *Decoder, store CheckpointStore, opts ScannerOptions) *Scanner {
+	streamOpts := opts.StreamOptions
+	streamOpts.EventTypes = []string{EventTypeMint, EventTypeBurn}
+	if opts.Asset == (Asset{}) {
+		opts.Asset = DefaultBTCAsset
+	}
+	return &Scanner{
+		stream:      NewStream(rpcClient, decoder, streamOpts),
+		store:       store,
+		startHeight: opts.StartHeight,
+		asset:       opts.Asset,
+		btcNetwork:  opts.BTCNetwork,
+	}
+}
+
+// Run scans the chain until ctx is done or handle fails. The checkpoint only moves past an
+// event once handle returned nil for it, so an event handle failed on is handed over again
+// on the next run. Events failing to parse end the scan as well, since skipping them would
+// lose them.
+func (s *Scanner) Run(ctx context.Context, handle func(event *ScannedEvent) error) error {
+	checkpoint, err := s.store.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load the checkpoint: %w", err)
+	}
+
+	fromHeight := s.startHeight
+	if checkpoint != nil {
+		fromHeight = checkpoint.Height
+	}
+
+	return s.stream.Run(ctx, fromHeight, func(block *Block) error {
+		for _, event := range block.Events {
+			if checkpoint != nil && block.Height == checkpoint.Height && event.Index < checkpoint.EventIndex {
+				continue
+			}
+
+			scanned, err := newScannedEvent(event, s.asset, s.btcNetwork)
+			if err != nil {
+				return err
+			}
+			if err := handle(scanned); err != nil {
+				return err
+			}
+			if err := s.store.Save(Checkpoint{Height: block.Height, EventIndex: event.Index + 1}); err != nil {
+				return fmt.Errorf("failed to save the checkpoint: %w", err)
+			}
+		}
+
+		if err := s.store.Save(Checkpoint{Height: block.Height + 1}); err != nil {
+			return fmt.Errorf("failed to save the checkpoint: %w", err)
+		}
+		return nil
+	})
+}
+
+// newScannedEvent parses a mint or burn event of a block
+func newScannedEvent(event *BlockEvent, asset Asset, btcNetwork string) (*ScannedEvent, error) {
+	scanned := &ScannedEvent{
+		Height:     event.Height,
+		TxHash:     fmt.Sprintf("%X", event.TxHash),
+		EventIndex: event.Index,
+	}
+
+	var err error
+	switch event.Type {
+	case EventTypeMint:
+		scanned.Mint, err = NewMintEventWithAsset(event.Event.Event, asset)
+	case EventTypeBurn:
+		scanned.Burn, err = ParseBurnEvent(event.Event.Event, asset, btcNetwork)
+	default:
+		err = fmt.Errorf("unexpected event type")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s event %d of block %d: %w", event.Type, event.Index, event.Height, err)
+	}
+	return scanned, nil
+}
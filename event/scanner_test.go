@@ -0,0 +1,98 @@
+package event_test
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	lorenzo "github.com/Lorenzo-Protocol/lorenzo/v3/app"
+	btcstakingtypes "github.com/Lorenzo-Protocol/lorenzo/v3/x/btcstaking/types"
+	abci_types "github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Lorenzo-Protocol/lorenzo-sdk/v3/address"
+	"github.com/Lorenzo-Protocol/lorenzo-sdk/v3/event"
+)
+
+func TestScanner(t *testing.T) {
+	signer := address.ToBech32(make([]byte, 20))
+	chain := newFakeChain(3, func(height int64) ([]abci_types.Event, error) {
+		mint, err := sdk.TypedEventToEvent(&btcstakingtypes.EventBTCStakingCreated{Record: &btcstakingtypes.BTCStakingRecord{
+			TxHash:       make([]byte, 32),
+			Amount:       uint64(height),
+			ReceiverAddr: make([]byte, 20),
+		}})
+		if err != nil {
+			return nil, err
+		}
+		burn, err := sdk.TypedEventToEvent(&btcstakingtypes.EventBurnCreated{
+			Signer:           signer,
+			BtcTargetAddress: "tb1qw508d6qejxtdg4y5r3zarvary0c5xw7kxpjzsx",
+			Amount:           sdk.NewInt64Coin("stBTC", height*1e10),
+		})
+		return []abci_types.Event{abci_types.Event(mint), abci_types.Event(burn)}, err
+	})
+	store, err := event.NewFileCheckpointStore(filepath.Join(t.TempDir(), "scanner", "checkpoint.json"))
+	require.NoError(t, err)
+
+	decoder := event.NewDecoder(lorenzo.MakeEncodingConfig().InterfaceRegistry)
+	scanner := event.NewScanner(chain, decoder, store, event.ScannerOptions{
+		StreamOptions: event.StreamOptions{PollInterval: 10 * time.Millisecond},
+		StartHeight:   2,
+		BTCNetwork:    address.BTCTestnet,
+	})
+
+	// the checkpoint stops at the event the handler failed on
+	errHandler := errors.New("handler failed")
+	var scanned []*event.ScannedEvent
+	err = scanner.Run(context.Background(), func(e *event.ScannedEvent) error {
+		if e.Burn != nil {
+			return errHandler
+		}
+		scanned = append(scanned, e)
+		return nil
+	})
+	require.ErrorIs(t, err, errHandler)
+	require.Len(t, scanned, 1)
+	require.Equal(t, int64(2), scanned[0].Height)
+	require.Equal(t, 1, scanned[0].EventIndex)
+	require.Equal(t, types.Tx("succeeded").Hash(), mustDecodeHex(t, scanned[0].TxHash))
+
+	checkpoint, err := store.Load()
+	require.NoError(t, err)
+	require.Equal(t, &event.Checkpoint{Height: 2, EventIndex: 2}, checkpoint)
+
+	// the next run resumes at that event
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	scanned = nil
+	err = scanner.Run(ctx, func(e *event.ScannedEvent) error {
+		scanned = append(scanned, e)
+		if e.Height == 3 && e.Burn != nil {
+			cancel()
+		}
+		return nil
+	})
+	require.ErrorIs(t, err, context.Canceled)
+	require.Len(t, scanned, 3)
+	require.Equal(t, int64(2), scanned[0].Height)
+	require.Equal(t, int64(2), scanned[0].Burn.ScaledAmount.Int64())
+	require.Equal(t, int64(3), scanned[1].Height)
+	require.NotNil(t, scanned[1].Mint)
+	require.Equal(t, int64(3), scanned[2].Burn.ScaledAmount.Int64())
+
+	checkpoint, err = store.Load()
+	require.NoError(t, err)
+	require.Equal(t, &event.Checkpoint{Height: 4}, checkpoint)
+}
+
+func mustDecodeHex(t *testing.T, s string) []byte {
+	bz, err := hex.DecodeString(s)
+	require.NoError(t, err)
+	return bz
+}
@@ -0,0 +1,427 @@
+package event
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	abci_types "github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/libs/service"
+	rpcclient "github.com/cometbft/cometbft/rpc/client"
+	coretypes "github.com/cometbft/cometbft/rpc/core/types"
+	"github.com/cometbft/cometbft/types"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultPollInterval      = 5 * time.Second
+	defaultStallTimeout      = 30 * time.Second
+	defaultReconnectDelay    = time.Second
+	defaultMaxReconnectDelay = 30 * time.Second
+	defaultCallTimeout       = 10 * time.Second
+)
+
+// subscriptions counts the subscriptions of all streams to give each one a unique subscriber
+var subscriptions atomic.Uint64
+
+// StreamOptions configures how a Stream follows the chain and recovers from failures
+type StreamOptions struct {
+	// EventTypes only streams the events of the given types, all events are streamed if empty
+	EventTypes []string
+	// PollInterval is the interval between two checks of the latest height, which catch up
+	// on the blocks the subscription missed, 5s if 0
+	PollInterval time.Duration
+	// StallTimeout is the time without new blocks after which the subscription is
+	// considered dead and renewed, 30s if 0
+	StallTimeout time.Duration
+	// ReconnectDelay is the delay before the first reconnection after a failure,
+	// doubled on every consecutive failure up to MaxReconnectDelay, 1s if 0
+	ReconnectDelay time.Duration
+	// MaxReconnectDelay caps the delay between two reconnections, 30s if 0
+	MaxReconnectDelay time.Duration
+	// Timeout bounds every RPC call, 10s if 0
+	Timeout time.Duration
+	// Logger logs the failures the stream recovers from, nothing is logged if nil
+	Logger *zap.Logger
+}
+
+// BlockEvent is an event emitted by a block, either by one of its txs or by BeginBlock or EndBlock
+type BlockEvent struct {
+	*Event
+	Height int64
+	// TxHash is the hash of the tx that emitted the event, nil for the events of BeginBlock and EndBlock
+	TxHash []byte
+	// TxIndex is the index of the tx that emitted the event in the block, -1 for the events of
+	// BeginBlock and EndBlock
+	TxIndex int
+	// Index is the index of the event among all the events of the block, filtered out or not,
+	// which identifies the event along with the height
+	Index int
+}
+
+// Block holds the events of a block streamed by a Stream, in the order they were emitted:
+// the ones of BeginBlock, then the ones of the successful txs, then the ones of EndBlock
+type Block struct {
+	Height int64
+	Events []*BlockEvent
+}
+
+// Stream follows the blocks of the chain and decodes their events. New blocks are notified
+// through a websocket subscription to the block headers, and every block is then read through
+// its block results, so the blocks missed while the subscription was down are backfilled.
+// A dropped or stalled subscription is renewed with exponential backoff.
+type Stream struct {
+	rpcClient rpcclient.Client
+	decoder   *Decoder
+	opts      StreamOptions
+	logger    *zap.Logger
+	types     map[string]struct{}
+}
+
+// NewStream creates a Stream reading blocks through the given RPC client and decoding their
+// events with the given decoder. The stream starts the RPC client if it is not running yet,
+// since the subscription goes through its websocket, and leaves stopping it to the caller.
+func NewStream(rpcClient rpcclient.Client, decoder *Decoder, opts StreamOptions) *Stream {
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = defaultPollInterval
+	}
+	if opts.StallTimeout <= 0 {
+		opts.StallTimeout = defaultStallTimeout
+	}
+	if opts.ReconnectDelay <= 0 {
+		opts.ReconnectDelay = defaultReconnectDelay
+	}
+	if opts.MaxReconnectDelay <= 0 {
+		opts.MaxReconnectDelay = defaultMaxReconnectDelay
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = defaultCallTimeout
+	}
+
+	logger := opts.Logger
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+
+	var eventTypes map[string]struct{}
+	if len(opts.EventTypes) > 0 {
+		eventTypes = make(map[string]struct{}, len(opts.EventTypes))
+		for _, eventType := range opts.EventTypes {
+			eventTypes[eventType] = struct{}{}
+		}
+	}
+
+	return &Stream{
+		rpcClient: rpcClient,
+		decoder:   decoder,
+		opts:      opts,
+		logger:    logger,
+		types:     eventTypes,
+	}
+}
+
+// fatalError wraps the errors retrying cannot recover from, i.e. the ones of the handler of Run and
+// the events failing to decode, so that they end the stream
+type fatalError struct {
+	err error
+}
+
+func (e *fatalError) Error() string {
+	return e.err.Error()
+}
+
+func (e *fatalError) Unwrap() error {
+	return e.err
+}
+
+// Run streams the blocks from fromHeight on, or from the next block if fromHeight is not positive,
+// calling handle for every block in height order, including the blocks without events. Every block
+// is handled exactly once, even across reconnections. Run returns when ctx is done, with the error
+// of ctx, or when handle fails or an event fails to decode, with the error of either.
+func (s *Stream) Run(ctx context.Context, fromHeight int64, handle func(block *Block) error) error {
+	next := fromHeight
+	if next <= 0 {
+		latest, err := s.latestHeight(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get the latest height: %w", err)
+		}
+		next = latest + 1
+	}
+
+	var (
+		sub      *subscription
+		failures int
+	)
+	defer func() {
+		sub.close()
+	}()
+
+	for {
+		err := s.subscribe(ctx, &sub)
+		if err == nil {
+			next, err = s.catchUp(ctx, next, handle)
+		}
+		var fatalErr *fatalError
+		if errors.As(err, &fatalErr) {
+			return fatalErr.err
+		}
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+
+		if err != nil {
+			failures++
+			delay := s.backoff(failures)
+			s.logger.Warn("event stream failed, reconnecting",
+				zap.Int64("next_height", next), zap.Int("failures", failures), zap.Duration("delay", delay), zap.Error(err))
+			sub.close()
+			sub = nil
+			if err := sleep(ctx, delay); err != nil {
+				return err
+			}
+			continue
+		}
+		failures = 0
+
+		if err := s.waitForBlock(ctx, &sub); err != nil {
+			return err
+		}
+	}
+}
+
+// Subscribe is like Run but hands the blocks over through the returned channel, which is closed
+// once the stream ends. The error the stream ended with is then sent on the error channel.
+func (s *Stream) Subscribe(ctx context.Context, fromHeight int64) (<-chan *Block, <-chan error) {
+	blocks := make(chan *Block)
+	errs := make(chan error, 1)
+	go func() {
+		defer close(blocks)
+		errs <- s.Run(ctx, fromHeight, func(block *Block) error {
+			select {
+			case blocks <- block:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+	}()
+	return blocks, errs
+}
+
+// catchUp handles the blocks from next to the latest one, and returns the height of the next block
+func (s *Stream) catchUp(ctx context.Context, next int64, handle func(block *Block) error) (int64, error) {
+	latest, err := s.latestHeight(ctx)
+	if err != nil {
+		return next, fmt.Errorf("failed to get the latest height: %w", err)
+	}
+	for ; next <= latest; next++ {
+		block, err := s.block(ctx, next)
+		if err != nil {
+			return next, fmt.Errorf("failed to read block %d: %w", next, err)
+		}
+		if err := handle(block); err != nil {
+			return next, &fatalError{err: err}
+		}
+	}
+	return next, nil
+}
+
+// block reads the block at the given height out of its block results
+func (s *Stream) block(ctx context.Context, height int64) (*Block, error) {
+	callCtx, cancel := context.WithTimeout(ctx, s.opts.Timeout)
+	defer cancel()
+
+	results, err := s.rpcClient.BlockResults(callCtx, &height)
+	if err != nil {
+		return nil, err
+	}
+
+	block := &Block{Height: height}
+	index := 0
+	if err := s.appendEvents(block, results.BeginBlockEvents, -1, &index); err != nil {
+		return nil, err
+	}
+	hasTxEvents := false
+	for i, txResult := range results.TxsResults {
+		// the state changes of failed txs are reverted, and so are their events
+		if txResult == nil {
+			continue
+		}
+		if txResult.IsErr() {
+			index += len(txResult.Events)
+			continue
+		}
+		n := len(block.Events)
+		if err := s.appendEvents(block, txResult.Events, i, &index); err != nil {
+			return nil, err
+		}
+		hasTxEvents = hasTxEvents || len(block.Events) > n
+	}
+	if err := s.appendEvents(block, results.EndBlockEvents, -1, &index); err != nil {
+		return nil, err
+	}
+
+	// block results carry no tx hashes, which are only read if some events need them
+	if hasTxEvents {
+		resBlock, err := s.rpcClient.Block(callCtx, &height)
+		if err != nil {
+			return nil, err
+		}
+		for _, event := range block.Events {
+			if event.TxIndex < 0 {
+				continue
+			}
+			if event.TxIndex >= len(resBlock.Block.Txs) {
+				return nil, fmt.Errorf("block %d has no tx %d", height, event.TxIndex)
+			}
+			event.TxHash = resBlock.Block.Txs[event.TxIndex].Hash()
+		}
+	}
+
+	return block, nil
+}
+
+// appendEvents decodes the events the stream is interested in and appends them to block,
+// index being the index in the block of the first of the given events
+func (s *Stream) appendEvents(block *Block, events []abci_types.Event, txIndex int, index *int) error {
+	for _, event := range events {
+		*index++
+		if s.types != nil {
+			if _, ok := s.types[event.Type]; !ok {
+				continue
+			}
+		}
+		decoded, err := s.decoder.Decode(event)
+		if err != nil {
+			return &fatalError{err: fmt.Errorf("block %d: %w", block.Height, err)}
+		}
+		block.Events = append(block.Events, &BlockEvent{
+			Event:   decoded,
+			Height:  block.Height,
+			TxIndex: txIndex,
+			Index:   *index - 1,
+		})
+	}
+	return nil
+}
+
+// latestHeight returns the height of the latest block of the node
+func (s *Stream) latestHeight(ctx context.Context) (int64, error) {
+	callCtx, cancel := context.WithTimeout(ctx, s.opts.Timeout)
+	defer cancel()
+
+	status, err := s.rpcClient.Status(callCtx)
+	if err != nil {
+		return 0, err
+	}
+	return status.SyncInfo.LatestBlockHeight, nil
+}
+
+// backoff returns the delay before reconnecting after the given number of consecutive failures
+func (s *Stream) backoff(failures int) time.Duration {
+	delay := s.opts.ReconnectDelay
+	for i := 1; i < failures && delay < s.opts.MaxReconnectDelay; i++ {
+		delay *= 2
+	}
+	if delay > s.opts.MaxReconnectDelay {
+		delay = s.opts.MaxReconnectDelay
+	}
+	return delay
+}
+
+// subscription is a subscription of a stream to the new block headers
+type subscription struct {
+	rpcClient  rpcclient.Client
+	subscriber string
+	timeout    time.Duration
+	out        <-chan coretypes.ResultEvent
+	lastBlock  time.Time
+}
+
+// subscribe subscribes to the new block headers if the stream has no subscription yet,
+// starting the RPC client first if it is not running
+func (s *Stream) subscribe(ctx context.Context, sub **subscription) error {
+	if *sub != nil {
+		return nil
+	}
+	if !s.rpcClient.IsRunning() {
+		if err := s.rpcClient.Start(); err != nil && !errors.Is(err, service.ErrAlreadyStarted) {
+			return fmt.Errorf("failed to start the RPC client: %w", err)
+		}
+	}
+
+	subscriber := fmt.Sprintf("lorenzo-sdk-stream-%d", subscriptions.Add(1))
+	callCtx, cancel := context.WithTimeout(ctx, s.opts.Timeout)
+	defer cancel()
+
+	out, err := s.rpcClient.Subscribe(callCtx, subscriber, types.EventQueryNewBlockHeader.String())
+	if err != nil {
+		return fmt.Errorf("failed to subscribe to new blocks: %w", err)
+	}
+	*sub = &subscription{
+		rpcClient:  s.rpcClient,
+		subscriber: subscriber,
+		timeout:    s.opts.Timeout,
+		out:        out,
+		lastBlock:  time.Now(),
+	}
+	return nil
+}
+
+// close unsubscribes from the new block headers, which is a no-op on a nil subscription
+func (sub *subscription) close() {
+	if sub == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), sub.timeout)
+	defer cancel()
+	// the subscription may be gone along with the connection
+	_ = sub.rpcClient.UnsubscribeAll(ctx, sub.subscriber)
+}
+
+// waitForBlock waits for a new block header, or for the poll interval to elapse. It drops the
+// subscription if it got closed or stalled, so that the next iteration of Run renews it.
+func (s *Stream) waitForBlock(ctx context.Context, sub **subscription) error {
+	timer := time.NewTimer(s.opts.PollInterval)
+	defer timer.Stop()
+
+	var out <-chan coretypes.ResultEvent
+	if *sub != nil {
+		out = (*sub).out
+	}
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case _, ok := <-out:
+		if !ok {
+			s.logger.Warn("event stream subscription closed, resubscribing")
+			(*sub).close()
+			*sub = nil
+			return nil
+		}
+		(*sub).lastBlock = time.Now()
+	case <-timer.C:
+		if *sub != nil && time.Since((*sub).lastBlock) > s.opts.StallTimeout {
+			s.logger.Warn("event stream subscription stalled, resubscribing", zap.Duration("stall_timeout", s.opts.StallTimeout))
+			(*sub).close()
+			*sub = nil
+		}
+	}
+	return nil
+}
+
+// sleep waits for the given delay or for ctx to be done
+func sleep(ctx context.Context, delay time.Duration) error {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,178 @@
+package event_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	lorenzo "github.com/Lorenzo-Protocol/lorenzo/v3/app"
+	agenttypes "github.com/Lorenzo-Protocol/lorenzo/v3/x/agent/types"
+	abci_types "github.com/cometbft/cometbft/abci/types"
+	rpcclient "github.com/cometbft/cometbft/rpc/client"
+	coretypes "github.com/cometbft/cometbft/rpc/core/types"
+	"github.com/cometbft/cometbft/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Lorenzo-Protocol/lorenzo-sdk/v3/event"
+)
+
+// fakeChain is a chain whose blocks have a failed tx and a successful tx emitting the events
+// returned by txEvents, and whose subscriptions can be dropped
+type fakeChain struct {
+	rpcclient.Client
+	txEvents func(height int64) ([]abci_types.Event, error)
+
+	mu     sync.Mutex
+	height int64
+	down   bool
+	subs   map[string]chan coretypes.ResultEvent
+}
+
+func newFakeChain(height int64, txEvents func(height int64) ([]abci_types.Event, error)) *fakeChain {
+	return &fakeChain{
+		txEvents: txEvents,
+		height:   height,
+		subs:     map[string]chan coretypes.ResultEvent{},
+	}
+}
+
+func (f *fakeChain) IsRunning() bool {
+	return true
+}
+
+func (f *fakeChain) Status(context.Context) (*coretypes.ResultStatus, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.down {
+		return nil, errors.New("connection refused")
+	}
+	return &coretypes.ResultStatus{SyncInfo: coretypes.SyncInfo{LatestBlockHeight: f.height}}, nil
+}
+
+func (f *fakeChain) BlockResults(_ context.Context, height *int64) (*coretypes.ResultBlockResults, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.down {
+		return nil, errors.New("connection refused")
+	}
+
+	events, err := f.txEvents(*height)
+	if err != nil {
+		return nil, err
+	}
+	return &coretypes.ResultBlockResults{
+		Height: *height,
+		TxsResults: []*abci_types.ResponseDeliverTx{
+			{Code: 5, Events: events[:1]},
+			{Events: append(events, abci_types.Event{Type: "message"})},
+		},
+	}, nil
+}
+
+func (f *fakeChain) Block(_ context.Context, height *int64) (*coretypes.ResultBlock, error) {
+	return &coretypes.ResultBlock{Block: &types.Block{
+		Header: types.Header{Height: *height},
+		Data:   types.Data{Txs: types.Txs{types.Tx("failed"), types.Tx("succeeded")}},
+	}}, nil
+}
+
+func (f *fakeChain) Subscribe(_ context.Context, subscriber, _ string, _ ...int) (<-chan coretypes.ResultEvent, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.down {
+		return nil, errors.New("connection refused")
+	}
+	out := make(chan coretypes.ResultEvent, 100)
+	f.subs[subscriber] = out
+	return out, nil
+}
+
+func (f *fakeChain) UnsubscribeAll(_ context.Context, subscriber string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.subs, subscriber)
+	return nil
+}
+
+// produce adds a block to the chain and notifies the subscribers
+func (f *fakeChain) produce() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.height++
+	for _, out := range f.subs {
+		out <- coretypes.ResultEvent{Data: types.EventDataNewBlockHeader{Header: types.Header{Height: f.height}}}
+	}
+}
+
+// setDown stops or restarts the node, closing all subscriptions when it stops
+func (f *fakeChain) setDown(down bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.down = down
+	if down {
+		for subscriber, out := range f.subs {
+			close(out)
+			delete(f.subs, subscriber)
+		}
+	}
+}
+
+func TestStream(t *testing.T) {
+	chain := newFakeChain(3, func(height int64) ([]abci_types.Event, error) {
+		addAgent, err := sdk.TypedEventToEvent(&agenttypes.EventAddAgent{Id: uint64(height)})
+		return []abci_types.Event{abci_types.Event(addAgent)}, err
+	})
+	decoder := event.NewDecoder(lorenzo.MakeEncodingConfig().InterfaceRegistry)
+	stream := event.NewStream(chain, decoder, event.StreamOptions{
+		EventTypes:        []string{"lorenzo.agent.v1.EventAddAgent"},
+		PollInterval:      time.Hour,
+		ReconnectDelay:    time.Millisecond,
+		MaxReconnectDelay: 10 * time.Millisecond,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	blocks, errs := stream.Subscribe(ctx, 2)
+
+	next := func() *event.Block {
+		select {
+		case block := <-blocks:
+			return block
+		case <-ctx.Done():
+			t.Fatal("no block streamed")
+			return nil
+		}
+	}
+
+	// the blocks up to the latest one are backfilled, and new ones follow
+	for height := int64(2); height <= 3; height++ {
+		block := next()
+		require.Equal(t, height, block.Height)
+		require.Len(t, block.Events, 1)
+		require.Equal(t, &agenttypes.EventAddAgent{Id: uint64(height)}, block.Events[0].Value)
+		require.Equal(t, 1, block.Events[0].TxIndex)
+		require.Equal(t, 1, block.Events[0].Index)
+		require.Equal(t, types.Tx("succeeded").Hash(), block.Events[0].TxHash)
+	}
+	chain.produce()
+	require.Equal(t, int64(4), next().Height)
+
+	// the blocks produced while the node is down are backfilled once it is back, without duplicates
+	chain.setDown(true)
+	chain.produce()
+	chain.produce()
+	chain.setDown(false)
+	for height := int64(5); height <= 6; height++ {
+		require.Equal(t, height, next().Height)
+	}
+	chain.produce()
+	require.Equal(t, int64(7), next().Height)
+
+	cancel()
+	for range blocks {
+	}
+	require.ErrorIs(t, <-errs, context.Canceled)
+}
@@ -0,0 +1,48 @@
+package event
+
+import (
+	tokentypes "github.com/Lorenzo-Protocol/lorenzo/v3/x/token/types"
+	abci_types "github.com/cometbft/cometbft/abci/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// x/token emits attribute events, which are decoded into the proto events of the module
+
+// decodeRegisterPair decodes the register_coin and register_erc20 events
+func decodeRegisterPair(event abci_types.Event) (interface{}, error) {
+	attrs := attributes(event)
+	return &tokentypes.EventRegisterPair{
+		Denom:           attrs[tokentypes.AttributeKeyCosmosCoin],
+		ContractAddress: attrs[tokentypes.AttributeKeyERC20Token],
+	}, nil
+}
+
+func decodeToggleTokenConversion(event abci_types.Event) (interface{}, error) {
+	attrs := attributes(event)
+	return &tokentypes.EventToggleTokenConversion{
+		Denom:           attrs[tokentypes.AttributeKeyCosmosCoin],
+		ContractAddress: attrs[tokentypes.AttributeKeyERC20Token],
+	}, nil
+}
+
+func decodeConvertCoin(event abci_types.Event) (interface{}, error) {
+	attrs := attributes(event)
+	return &tokentypes.EventConvertCoin{
+		Sender:          attrs[sdk.AttributeKeySender],
+		Receiver:        attrs[tokentypes.AttributeKeyReceiver],
+		Amount:          attrs[sdk.AttributeKeyAmount],
+		Denom:           attrs[tokentypes.AttributeKeyCosmosCoin],
+		ContractAddress: attrs[tokentypes.AttributeKeyERC20Token],
+	}, nil
+}
+
+func decodeConvertERC20(event abci_types.Event) (interface{}, error) {
+	attrs := attributes(event)
+	return &tokentypes.EventConvertERC20{
+		Sender:          attrs[sdk.AttributeKeySender],
+		Receiver:        attrs[tokentypes.AttributeKeyReceiver],
+		Amount:          attrs[sdk.AttributeKeyAmount],
+		Denom:           attrs[tokentypes.AttributeKeyCosmosCoin],
+		ContractAddress: attrs[tokentypes.AttributeKeyERC20Token],
+	}, nil
+}
@@ -0,0 +1,72 @@
+// Package evm talks to the EVM side of a Lorenzo node through its Ethereum JSON-RPC,
+// e.g. to read the ERC20 contracts of the token pairs of x/token and to send EVM txs.
+package evm
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// Client is a client of the Ethereum JSON-RPC of a Lorenzo node.
+// Besides its own helpers, it inherits all the methods of the go-ethereum client.
+type Client struct {
+	*ethclient.Client
+
+	timeout time.Duration
+
+	chainIDMu sync.Mutex
+	chainID   *big.Int
+
+	// sendMu serializes the sends, so that two txs of a signer do not get the same nonce
+	sendMu sync.Mutex
+}
+
+// New dials the Ethereum JSON-RPC at addr, e.g. http://localhost:8545.
+// The calls of the helpers of the client are bounded by timeout if it is positive.
+func New(addr string, timeout time.Duration) (*Client, error) {
+	rpcClient, err := rpc.Dial(addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial the EVM JSON-RPC %s: %w", addr, err)
+	}
+	return NewWithRPCClient(rpcClient, timeout), nil
+}
+
+// NewWithRPCClient creates a new Client with a given existing JSON-RPC client and timeout
+func NewWithRPCClient(rpcClient *rpc.Client, timeout time.Duration) *Client {
+	return &Client{
+		Client:  ethclient.NewClient(rpcClient),
+		timeout: timeout,
+	}
+}
+
+// ChainID returns the EIP-155 chain ID of the node, which is only queried once
+func (c *Client) ChainID(ctx context.Context) (*big.Int, error) {
+	c.chainIDMu.Lock()
+	defer c.chainIDMu.Unlock()
+
+	if c.chainID == nil {
+		ctx, cancel := c.callContext(ctx)
+		defer cancel()
+
+		chainID, err := c.Client.ChainID(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query the chain ID: %w", err)
+		}
+		c.chainID = chainID
+	}
+	return new(big.Int).Set(c.chainID), nil
+}
+
+// callContext bounds a call to the node by the timeout of the client
+func (c *Client) callContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if c.timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, c.timeout)
+}
@@ -0,0 +1,137 @@
+package evm_test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	lorenzo "github.com/Lorenzo-Protocol/lorenzo/v3/app"
+	"github.com/Lorenzo-Protocol/lorenzo/v3/contracts/erc20"
+	"github.com/cosmos/cosmos-sdk/crypto/hd"
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	ethhd "github.com/evmos/ethermint/crypto/hd"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Lorenzo-Protocol/lorenzo-sdk/v3/evm"
+)
+
+var (
+	chainID = big.NewInt(83291)
+	token   = common.HexToAddress("0x80b5a32E4F032B2a058b4F29EC95EEfEEB87aDcd")
+)
+
+// fakeEth serves the few eth_ methods used by the client
+type fakeEth struct {
+	balances map[common.Address]*big.Int
+	sent     []*types.Transaction
+}
+
+func (f *fakeEth) ChainId() *hexutil.Big {
+	return (*hexutil.Big)(chainID)
+}
+
+func (f *fakeEth) Call(args map[string]interface{}, _ string) (hexutil.Bytes, error) {
+	data, err := hexutil.Decode(args["data"].(string))
+	if err != nil {
+		return nil, err
+	}
+	method, err := erc20.ERC20MinterBurnerDecimalsContract.ABI.MethodById(data[:4])
+	if err != nil {
+		return nil, err
+	}
+	in, err := method.Inputs.Unpack(data[4:])
+	if err != nil {
+		return nil, err
+	}
+	balance, ok := f.balances[in[0].(common.Address)]
+	if !ok {
+		balance = new(big.Int)
+	}
+	return method.Outputs.Pack(balance)
+}
+
+func (f *fakeEth) SendRawTransaction(raw hexutil.Bytes) (common.Hash, error) {
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(raw); err != nil {
+		return common.Hash{}, err
+	}
+	f.sent = append(f.sent, tx)
+	return tx.Hash(), nil
+}
+
+func (f *fakeEth) GetTransactionReceipt(hash common.Hash) (*types.Receipt, error) {
+	for _, tx := range f.sent {
+		if tx.Hash() == hash {
+			return &types.Receipt{
+				Status:      types.ReceiptStatusSuccessful,
+				TxHash:      hash,
+				GasUsed:     tx.Gas(),
+				Logs:        []*types.Log{},
+				BlockNumber: big.NewInt(1),
+			}, nil
+		}
+	}
+	return nil, nil
+}
+
+func newTestClient(t *testing.T) (*evm.Client, *fakeEth) {
+	fake := &fakeEth{balances: map[common.Address]*big.Int{}}
+	server := rpc.NewServer()
+	require.NoError(t, server.RegisterName("eth", fake))
+	t.Cleanup(server.Stop)
+	return evm.NewWithRPCClient(rpc.DialInProc(server), time.Second), fake
+}
+
+func TestClient(t *testing.T) {
+	c, fake := newTestClient(t)
+	ctx := context.Background()
+
+	kr := keyring.NewInMemory(lorenzo.MakeEncodingConfig().Codec, ethhd.EthSecp256k1Option())
+	_, _, err := kr.NewMnemonic("relayer", keyring.English, "m/44'/60'/0'/0/0", keyring.DefaultBIP39Passphrase, ethhd.EthSecp256k1)
+	require.NoError(t, err)
+	signer, err := evm.NewKeyringSigner(kr, "relayer")
+	require.NoError(t, err)
+
+	fake.balances[signer.Address()] = big.NewInt(42)
+	balance, err := c.BalanceOf(ctx, token, signer.Address())
+	require.NoError(t, err)
+	require.Equal(t, int64(42), balance.Int64())
+
+	// EIP-1559 txs are signed by the key
+	hash, err := c.TransferERC20(ctx, signer, token, common.HexToAddress("0x01"), big.NewInt(1),
+		evm.WithNonce(5), evm.WithGasLimit(60000), evm.WithGasTipCap(big.NewInt(1)), evm.WithGasFeeCap(big.NewInt(10)))
+	require.NoError(t, err)
+	require.Len(t, fake.sent, 1)
+	sent := fake.sent[0]
+	require.Equal(t, hash, sent.Hash())
+	require.Equal(t, uint8(types.DynamicFeeTxType), sent.Type())
+	require.Equal(t, uint64(5), sent.Nonce())
+	require.Equal(t, token, *sent.To())
+	sender, err := types.Sender(types.LatestSignerForChainID(chainID), sent)
+	require.NoError(t, err)
+	require.Equal(t, signer.Address(), sender)
+
+	receipt, err := c.WaitForReceipt(ctx, hash)
+	require.NoError(t, err)
+	require.Equal(t, hash, receipt.TxHash)
+
+	// so are legacy txs
+	_, err = c.SendTx(ctx, signer, &token, big.NewInt(1), nil, evm.WithNonce(6), evm.WithGasLimit(21000), evm.WithGasPrice(big.NewInt(10)))
+	require.NoError(t, err)
+	require.Equal(t, uint8(types.LegacyTxType), fake.sent[1].Type())
+	sender, err = types.Sender(types.LatestSignerForChainID(chainID), fake.sent[1])
+	require.NoError(t, err)
+	require.Equal(t, signer.Address(), sender)
+
+	// Cosmos SDK keys cannot sign EVM txs
+	_, _, err = kr.NewMnemonic("cosmos", keyring.English, sdk.FullFundraiserPath, keyring.DefaultBIP39Passphrase, hd.Secp256k1)
+	require.NoError(t, err)
+	_, err = evm.NewKeyringSigner(kr, "cosmos")
+	require.Error(t, err)
+}
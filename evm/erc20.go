@@ -0,0 +1,97 @@
+package evm
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/Lorenzo-Protocol/lorenzo/v3/contracts/erc20"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// erc20ABI is the ABI of the ERC20 contracts deployed by x/token for its token pairs
+var erc20ABI = erc20.ERC20MinterBurnerDecimalsContract.ABI
+
+// BalanceOf returns the balance of owner in the ERC20 contract at token
+func (c *Client) BalanceOf(ctx context.Context, token, owner common.Address) (*big.Int, error) {
+	return c.callERC20Uint(ctx, token, "balanceOf", owner)
+}
+
+// Allowance returns the amount of tokens of the ERC20 contract at token that spender can spend on behalf of owner
+func (c *Client) Allowance(ctx context.Context, token, owner, spender common.Address) (*big.Int, error) {
+	return c.callERC20Uint(ctx, token, "allowance", owner, spender)
+}
+
+// TotalSupply returns the total supply of the ERC20 contract at token
+func (c *Client) TotalSupply(ctx context.Context, token common.Address) (*big.Int, error) {
+	return c.callERC20Uint(ctx, token, "totalSupply")
+}
+
+// Decimals returns the decimals of the ERC20 contract at token
+func (c *Client) Decimals(ctx context.Context, token common.Address) (uint8, error) {
+	out, err := c.callERC20(ctx, token, "decimals")
+	if err != nil {
+		return 0, err
+	}
+	decimals, ok := out[0].(uint8)
+	if !ok {
+		return 0, fmt.Errorf("unexpected decimals of type %T", out[0])
+	}
+	return decimals, nil
+}
+
+// TransferERC20 sends a tx transferring amount tokens of the ERC20 contract at token from signer to to
+func (c *Client) TransferERC20(ctx context.Context, signer Signer, token, to common.Address, amount *big.Int, opts ...TxOption) (common.Hash, error) {
+	data, err := erc20ABI.Pack("transfer", to, amount)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return c.SendTx(ctx, signer, &token, nil, data, opts...)
+}
+
+// ApproveERC20 sends a tx allowing spender to spend amount tokens of the ERC20 contract at token on behalf of signer
+func (c *Client) ApproveERC20(ctx context.Context, signer Signer, token, spender common.Address, amount *big.Int, opts ...TxOption) (common.Hash, error) {
+	data, err := erc20ABI.Pack("approve", spender, amount)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return c.SendTx(ctx, signer, &token, nil, data, opts...)
+}
+
+// callERC20Uint calls a method of the ERC20 contract at token returning a single uint256
+func (c *Client) callERC20Uint(ctx context.Context, token common.Address, method string, args ...interface{}) (*big.Int, error) {
+	out, err := c.callERC20(ctx, token, method, args...)
+	if err != nil {
+		return nil, err
+	}
+	amount, ok := out[0].(*big.Int)
+	if !ok {
+		return nil, fmt.Errorf("unexpected %s of type %T", method, out[0])
+	}
+	return amount, nil
+}
+
+// callERC20 calls a read-only method of the ERC20 contract at token against the latest state
+func (c *Client) callERC20(ctx context.Context, token common.Address, method string, args ...interface{}) ([]interface{}, error) {
+	data, err := erc20ABI.Pack(method, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := c.callContext(ctx)
+	defer cancel()
+
+	res, err := c.CallContract(ctx, ethereum.CallMsg{To: &token, Data: data}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call %s of %s: %w", method, token, err)
+	}
+	out, err := erc20ABI.Unpack(method, res)
+	if err != nil {
+		return nil, fmt.Errorf("invalid result of %s of %s: %w", method, token, err)
+	}
+	if len(out) == 0 {
+		return nil, fmt.Errorf("empty result of %s of %s", method, token)
+	}
+	return out, nil
+}
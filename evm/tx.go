@@ -0,0 +1,275 @@
+package evm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/evmos/ethermint/crypto/ethsecp256k1"
+)
+
+// receiptPollInterval is the interval between two lookups of a receipt of a tx waiting for inclusion
+const receiptPollInterval = time.Second
+
+// ErrTxReverted is returned along with the receipt of a tx whose execution failed
+var ErrTxReverted = errors.New("evm tx reverted")
+
+// Signer signs EVM txs on behalf of an account
+type Signer interface {
+	// Address is the address of the account
+	Address() common.Address
+	// SignHash signs hash and returns the signature in the [R || S || V] format, V being 0 or 1
+	SignHash(hash common.Hash) ([]byte, error)
+}
+
+// KeyringSigner is a Signer backed by an eth_secp256k1 key of a Cosmos SDK keyring,
+// so that the keys signing the Cosmos txs of a Lorenzo account also sign its EVM txs
+type KeyringSigner struct {
+	kr      keyring.Keyring
+	name    string
+	address common.Address
+}
+
+// NewKeyringSigner returns a Signer backed by the key of kr with the given name
+func NewKeyringSigner(kr keyring.Keyring, name string) (*KeyringSigner, error) {
+	record, err := kr.Key(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get key %s: %w", name, err)
+	}
+	pubKey, err := record.GetPubKey()
+	if err != nil {
+		return nil, err
+	}
+	if pubKey.Type() != ethsecp256k1.KeyType {
+		return nil, fmt.Errorf("EVM txs must be signed by an %s key, %s is a %s key", ethsecp256k1.KeyType, name, pubKey.Type())
+	}
+	return &KeyringSigner{
+		kr:      kr,
+		name:    name,
+		address: common.BytesToAddress(pubKey.Address()),
+	}, nil
+}
+
+// Address returns the address of the key
+func (s *KeyringSigner) Address() common.Address {
+	return s.address
+}
+
+// SignHash signs hash with the key
+func (s *KeyringSigner) SignHash(hash common.Hash) ([]byte, error) {
+	// eth_secp256k1 keys sign a 32 bytes hash as is
+	sig, _, err := s.kr.Sign(s.name, hash.Bytes())
+	return sig, err
+}
+
+// TxOptions overrides the defaults of a single EVM tx
+type TxOptions struct {
+	// Nonce is the nonce of the tx, nil uses the pending nonce of the signer
+	Nonce *uint64
+	// GasLimit is the gas limit of the tx, 0 estimates it
+	GasLimit uint64
+	// GasPrice sends a legacy tx with the given gas price
+	GasPrice *big.Int
+	// GasTipCap is the tip of an EIP-1559 tx, nil uses the one suggested by the node
+	GasTipCap *big.Int
+	// GasFeeCap is the max fee per gas of an EIP-1559 tx, nil uses the tip plus twice the base fee
+	GasFeeCap *big.Int
+}
+
+type TxOption func(*TxOptions)
+
+// WithNonce sends the tx with the given nonce
+func WithNonce(nonce uint64) TxOption {
+	return func(options *TxOptions) {
+		options.Nonce = &nonce
+	}
+}
+
+// WithGasLimit sends the tx with the given gas limit instead of estimating it
+func WithGasLimit(gasLimit uint64) TxOption {
+	return func(options *TxOptions) {
+		options.GasLimit = gasLimit
+	}
+}
+
+// WithGasPrice sends a legacy tx with the given gas price
+func WithGasPrice(gasPrice *big.Int) TxOption {
+	return func(options *TxOptions) {
+		options.GasPrice = gasPrice
+	}
+}
+
+// WithGasTipCap sends an EIP-1559 tx with the given tip
+func WithGasTipCap(gasTipCap *big.Int) TxOption {
+	return func(options *TxOptions) {
+		options.GasTipCap = gasTipCap
+	}
+}
+
+// WithGasFeeCap sends an EIP-1559 tx with the given max fee per gas
+func WithGasFeeCap(gasFeeCap *big.Int) TxOption {
+	return func(options *TxOptions) {
+		options.GasFeeCap = gasFeeCap
+	}
+}
+
+func newTxOptions(opts ...TxOption) TxOptions {
+	var options TxOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return options
+}
+
+// SendTx signs a tx calling to with the given value and data and sends it to the node, returning its hash
+// once the node accepted it. A nil to deploys a contract. Use WaitForReceipt to wait for its inclusion.
+// Unless opts set them, the nonce is the pending one of the signer, the gas limit is estimated and the tx is
+// an EIP-1559 tx paying the suggested tip, or a legacy tx at the suggested gas price if the chain has no base fee.
+func (c *Client) SendTx(ctx context.Context, signer Signer, to *common.Address, value *big.Int, data []byte, opts ...TxOption) (common.Hash, error) {
+	options := newTxOptions(opts...)
+	if value == nil {
+		value = new(big.Int)
+	}
+
+	chainID, err := c.ChainID(ctx)
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	c.sendMu.Lock()
+	defer c.sendMu.Unlock()
+
+	ctx, cancel := c.callContext(ctx)
+	defer cancel()
+
+	from := signer.Address()
+	nonce := options.Nonce
+	if nonce == nil {
+		pending, err := c.PendingNonceAt(ctx, from)
+		if err != nil {
+			return common.Hash{}, fmt.Errorf("failed to query the nonce of %s: %w", from, err)
+		}
+		nonce = &pending
+	}
+
+	gasLimit := options.GasLimit
+	if gasLimit == 0 {
+		gasLimit, err = c.EstimateGas(ctx, ethereum.CallMsg{From: from, To: to, Value: value, Data: data})
+		if err != nil {
+			return common.Hash{}, fmt.Errorf("failed to estimate the gas of the tx: %w", err)
+		}
+	}
+
+	txData, err := c.feeTxData(ctx, options, *nonce, gasLimit, to, value, data)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	tx := types.NewTx(txData)
+
+	txSigner := types.LatestSignerForChainID(chainID)
+	sig, err := signer.SignHash(txSigner.Hash(tx))
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to sign the tx: %w", err)
+	}
+	signedTx, err := tx.WithSignature(txSigner, sig)
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	if err := c.SendTransaction(ctx, signedTx); err != nil {
+		return common.Hash{}, err
+	}
+	return signedTx.Hash(), nil
+}
+
+// feeTxData returns the data of a tx paying the fees of options, or the ones suggested by the node
+func (c *Client) feeTxData(ctx context.Context, options TxOptions, nonce, gasLimit uint64, to *common.Address, value *big.Int, data []byte) (types.TxData, error) {
+	legacy := func(gasPrice *big.Int) types.TxData {
+		return &types.LegacyTx{Nonce: nonce, GasPrice: gasPrice, Gas: gasLimit, To: to, Value: value, Data: data}
+	}
+	if options.GasPrice != nil {
+		return legacy(options.GasPrice), nil
+	}
+
+	gasFeeCap := options.GasFeeCap
+	var baseFee *big.Int
+	if gasFeeCap == nil {
+		head, err := c.HeaderByNumber(ctx, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query the latest header: %w", err)
+		}
+		if head.BaseFee == nil {
+			// the chain has no base fee, fall back to a legacy tx
+			gasPrice, err := c.SuggestGasPrice(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to query the gas price: %w", err)
+			}
+			return legacy(gasPrice), nil
+		}
+		baseFee = head.BaseFee
+	}
+
+	gasTipCap := options.GasTipCap
+	if gasTipCap == nil {
+		var err error
+		if gasTipCap, err = c.SuggestGasTipCap(ctx); err != nil {
+			return nil, fmt.Errorf("failed to query the gas tip: %w", err)
+		}
+	}
+	if gasFeeCap == nil {
+		gasFeeCap = new(big.Int).Add(gasTipCap, new(big.Int).Mul(baseFee, big.NewInt(2)))
+	}
+	if gasTipCap.Cmp(gasFeeCap) > 0 {
+		return nil, fmt.Errorf("gas tip %s is higher than the max fee per gas %s", gasTipCap, gasFeeCap)
+	}
+
+	return &types.DynamicFeeTx{
+		Nonce:     nonce,
+		GasTipCap: gasTipCap,
+		GasFeeCap: gasFeeCap,
+		Gas:       gasLimit,
+		To:        to,
+		Value:     value,
+		Data:      data,
+	}, nil
+}
+
+// Receipt returns the receipt of the tx with the given hash, or ethereum.NotFound if it is not included yet
+func (c *Client) Receipt(ctx context.Context, hash common.Hash) (*types.Receipt, error) {
+	ctx, cancel := c.callContext(ctx)
+	defer cancel()
+
+	return c.TransactionReceipt(ctx, hash)
+}
+
+// WaitForReceipt polls the receipt of the tx with the given hash until the tx is included in a block,
+// or ctx is done. The receipt of a tx whose execution failed is returned along with ErrTxReverted.
+func (c *Client) WaitForReceipt(ctx context.Context, hash common.Hash) (*types.Receipt, error) {
+	ticker := time.NewTicker(receiptPollInterval)
+	defer ticker.Stop()
+
+	for {
+		receipt, err := c.Receipt(ctx, hash)
+		if err == nil {
+			if receipt.Status == types.ReceiptStatusFailed {
+				return receipt, fmt.Errorf("%w: %s", ErrTxReverted, hash)
+			}
+			return receipt, nil
+		}
+		if !errors.Is(err, ethereum.NotFound) && ctx.Err() == nil {
+			return nil, fmt.Errorf("failed to query the receipt of tx %s: %w", hash, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("failed to wait for tx %s to be included in a block: %w", hash, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
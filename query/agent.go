@@ -4,42 +4,86 @@ import (
 	"context"
 
 	"github.com/Lorenzo-Protocol/lorenzo/v3/x/agent/types"
-	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/types/query"
 )
 
-func (c *QueryClient) QueryAgent(f func(ctx context.Context, client types.QueryClient) error) error {
-	ctx, cancel := c.getQueryContext()
+func (c *QueryClient) QueryAgent(f func(ctx context.Context, client types.QueryClient) error, opts ...QueryOption) error {
+	return c.QueryAgentWithContext(context.Background(), f, opts...)
+}
+
+// QueryAgentWithContext is like QueryAgent but bounds the query by the given context
+func (c *QueryClient) QueryAgentWithContext(ctx context.Context, f func(ctx context.Context, client types.QueryClient) error, opts ...QueryOption) error {
+	options := newQueryOptions(opts...)
+	queryCtx, cancel := c.getQueryContext(ctx, options)
 	defer cancel()
 
-	clientCtx := client.Context{Client: c.RPCClient}
-	queryClient := types.NewQueryClient(clientCtx)
+	queryClient := types.NewQueryClient(c.getQueryConn(options))
+
+	return f(queryCtx, queryClient)
+}
 
-	return f(ctx, queryClient)
+func (c *QueryClient) Agents(pageRequest *query.PageRequest, opts ...QueryOption) (*types.QueryAgentsResponse, error) {
+	return c.AgentsWithContext(context.Background(), pageRequest, opts...)
 }
 
-func (c *QueryClient) Agents(pageRequest *query.PageRequest) (*types.QueryAgentsResponse, error) {
+// AgentsWithContext is like Agents but bounds the query by the given context
+func (c *QueryClient) AgentsWithContext(ctx context.Context, pageRequest *query.PageRequest, opts ...QueryOption) (*types.QueryAgentsResponse, error) {
 	var resp *types.QueryAgentsResponse
-	err := c.QueryAgent(func(ctx context.Context, queryClient types.QueryClient) error {
+	err := c.QueryAgentWithContext(ctx, func(ctx context.Context, queryClient types.QueryClient) error {
 		var err error
 		resp, err = queryClient.Agents(ctx, &types.QueryAgentsRequest{
 			Pagination: pageRequest,
 		})
 		return err
-	})
+	}, opts...)
 
 	return resp, err
 }
 
-func (c *QueryClient) Agent(agentId uint64) (*types.QueryAgentResponse, error) {
+func (c *QueryClient) Agent(agentId uint64, opts ...QueryOption) (*types.QueryAgentResponse, error) {
+	return c.AgentWithContext(context.Background(), agentId, opts...)
+}
+
+// AgentWithContext is like Agent but bounds the query by the given context
+func (c *QueryClient) AgentWithContext(ctx context.Context, agentId uint64, opts ...QueryOption) (*types.QueryAgentResponse, error) {
 	var resp *types.QueryAgentResponse
-	err := c.QueryAgent(func(ctx context.Context, queryClient types.QueryClient) error {
+	err := c.QueryAgentWithContext(ctx, func(ctx context.Context, queryClient types.QueryClient) error {
 		var err error
 		resp, err = queryClient.Agent(ctx, &types.QueryAgentRequest{
 			Id: agentId,
 		})
 		return err
-	})
+	}, opts...)
 
 	return resp, err
 }
+
+// WalkAgents calls f for every agent, fetching the agents page by page
+func (c *QueryClient) WalkAgents(ctx context.Context, f func(agent types.Agent) error, opts ...QueryOption) error {
+	return c.paginate(ctx, func(ctx context.Context, pageReq *query.PageRequest, opts ...QueryOption) ([]byte, error) {
+		resp, err := c.AgentsWithContext(ctx, pageReq, opts...)
+		if err != nil {
+			return nil, err
+		}
+		for _, agent := range resp.Agents {
+			if err := f(agent); err != nil {
+				return nil, err
+			}
+		}
+		return nextKey(resp.Pagination), nil
+	}, opts...)
+}
+
+// AllAgents returns all agents, following the pagination of the agents query
+func (c *QueryClient) AllAgents(ctx context.Context, opts ...QueryOption) ([]types.Agent, error) {
+	var agents []types.Agent
+	err := c.WalkAgents(ctx, func(agent types.Agent) error {
+		agents = append(agents, agent)
+		return nil
+	}, opts...)
+
+	if err != nil {
+		return nil, err
+	}
+	return agents, nil
+}
@@ -0,0 +1,120 @@
+package query
+
+import (
+	"context"
+
+	"github.com/cosmos/cosmos-sdk/types/query"
+	"github.com/cosmos/cosmos-sdk/x/authz"
+)
+
+// QueryAuthz queries the Authz module of the Lorenzo node
+// according to the given function
+func (c *QueryClient) QueryAuthz(f func(ctx context.Context, queryClient authz.QueryClient) error, opts ...QueryOption) error {
+	return c.QueryAuthzWithContext(context.Background(), f, opts...)
+}
+
+// QueryAuthzWithContext is like QueryAuthz but bounds the query by the given context
+func (c *QueryClient) QueryAuthzWithContext(ctx context.Context, f func(ctx context.Context, queryClient authz.QueryClient) error, opts ...QueryOption) error {
+	options := newQueryOptions(opts...)
+	queryCtx, cancel := c.getQueryContext(ctx, options)
+	defer cancel()
+
+	queryClient := authz.NewQueryClient(c.getQueryConn(options))
+
+	return f(queryCtx, queryClient)
+}
+
+// AuthzGrants queries the grants of granter to grantee, restricted to the given msg type URL if not empty
+func (c *QueryClient) AuthzGrants(granter, grantee, msgTypeURL string, pageRequest *query.PageRequest, opts ...QueryOption) (*authz.QueryGrantsResponse, error) {
+	return c.AuthzGrantsWithContext(context.Background(), granter, grantee, msgTypeURL, pageRequest, opts...)
+}
+
+// AuthzGrantsWithContext is like AuthzGrants but bounds the query by the given context
+func (c *QueryClient) AuthzGrantsWithContext(ctx context.Context, granter, grantee, msgTypeURL string, pageRequest *query.PageRequest, opts ...QueryOption) (*authz.QueryGrantsResponse, error) {
+	var resp *authz.QueryGrantsResponse
+	err := c.QueryAuthzWithContext(ctx, func(ctx context.Context, queryClient authz.QueryClient) error {
+		var err error
+		resp, err = queryClient.Grants(ctx, &authz.QueryGrantsRequest{
+			Granter:    granter,
+			Grantee:    grantee,
+			MsgTypeUrl: msgTypeURL,
+			Pagination: pageRequest,
+		})
+		return err
+	}, opts...)
+
+	return resp, err
+}
+
+// AuthzGranterGrants queries the grants given by granter
+func (c *QueryClient) AuthzGranterGrants(granter string, pageRequest *query.PageRequest, opts ...QueryOption) (*authz.QueryGranterGrantsResponse, error) {
+	return c.AuthzGranterGrantsWithContext(context.Background(), granter, pageRequest, opts...)
+}
+
+// AuthzGranterGrantsWithContext is like AuthzGranterGrants but bounds the query by the given context
+func (c *QueryClient) AuthzGranterGrantsWithContext(ctx context.Context, granter string, pageRequest *query.PageRequest, opts ...QueryOption) (*authz.QueryGranterGrantsResponse, error) {
+	var resp *authz.QueryGranterGrantsResponse
+	err := c.QueryAuthzWithContext(ctx, func(ctx context.Context, queryClient authz.QueryClient) error {
+		var err error
+		resp, err = queryClient.GranterGrants(ctx, &authz.QueryGranterGrantsRequest{
+			Granter:    granter,
+			Pagination: pageRequest,
+		})
+		return err
+	}, opts...)
+
+	return resp, err
+}
+
+// AuthzGranteeGrants queries the grants given to grantee
+func (c *QueryClient) AuthzGranteeGrants(grantee string, pageRequest *query.PageRequest, opts ...QueryOption) (*authz.QueryGranteeGrantsResponse, error) {
+	return c.AuthzGranteeGrantsWithContext(context.Background(), grantee, pageRequest, opts...)
+}
+
+// AuthzGranteeGrantsWithContext is like AuthzGranteeGrants but bounds the query by the given context
+func (c *QueryClient) AuthzGranteeGrantsWithContext(ctx context.Context, grantee string, pageRequest *query.PageRequest, opts ...QueryOption) (*authz.QueryGranteeGrantsResponse, error) {
+	var resp *authz.QueryGranteeGrantsResponse
+	err := c.QueryAuthzWithContext(ctx, func(ctx context.Context, queryClient authz.QueryClient) error {
+		var err error
+		resp, err = queryClient.GranteeGrants(ctx, &authz.QueryGranteeGrantsRequest{
+			Grantee:    grantee,
+			Pagination: pageRequest,
+		})
+		return err
+	}, opts...)
+
+	return resp, err
+}
+
+// WalkAuthzGrants calls f for every grant of granter to grantee, restricted to the given msg type URL
+// if not empty, fetching the grants page by page
+func (c *QueryClient) WalkAuthzGrants(ctx context.Context, granter, grantee, msgTypeURL string, f func(grant *authz.Grant) error, opts ...QueryOption) error {
+	return c.paginate(ctx, func(ctx context.Context, pageReq *query.PageRequest, opts ...QueryOption) ([]byte, error) {
+		resp, err := c.AuthzGrantsWithContext(ctx, granter, grantee, msgTypeURL, pageReq, opts...)
+		if err != nil {
+			return nil, err
+		}
+		for _, grant := range resp.Grants {
+			if err := f(grant); err != nil {
+				return nil, err
+			}
+		}
+		return nextKey(resp.Pagination), nil
+	}, opts...)
+}
+
+// WalkAuthzGranteeGrants calls f for every grant given to grantee, fetching the grants page by page
+func (c *QueryClient) WalkAuthzGranteeGrants(ctx context.Context, grantee string, f func(grant *authz.GrantAuthorization) error, opts ...QueryOption) error {
+	return c.paginate(ctx, func(ctx context.Context, pageReq *query.PageRequest, opts ...QueryOption) ([]byte, error) {
+		resp, err := c.AuthzGranteeGrantsWithContext(ctx, grantee, pageReq, opts...)
+		if err != nil {
+			return nil, err
+		}
+		for _, grant := range resp.Grants {
+			if err := f(grant); err != nil {
+				return nil, err
+			}
+		}
+		return nextKey(resp.Pagination), nil
+	}, opts...)
+}
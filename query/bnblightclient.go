@@ -4,30 +4,39 @@ import (
 	"context"
 
 	"github.com/Lorenzo-Protocol/lorenzo/v3/x/bnblightclient/types"
-	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/ethereum/go-ethereum/common"
 )
 
-func (c *QueryClient) QueryBNBLightClient(f func(ctx context.Context, client types.QueryClient) error) error {
-	ctx, cancel := c.getQueryContext()
+func (c *QueryClient) QueryBNBLightClient(f func(ctx context.Context, client types.QueryClient) error, opts ...QueryOption) error {
+	return c.QueryBNBLightClientWithContext(context.Background(), f, opts...)
+}
+
+// QueryBNBLightClientWithContext is like QueryBNBLightClient but bounds the query by the given context
+func (c *QueryClient) QueryBNBLightClientWithContext(ctx context.Context, f func(ctx context.Context, client types.QueryClient) error, opts ...QueryOption) error {
+	options := newQueryOptions(opts...)
+	queryCtx, cancel := c.getQueryContext(ctx, options)
 	defer cancel()
 
-	clientCtx := client.Context{Client: c.RPCClient}
-	queryClient := types.NewQueryClient(clientCtx)
+	queryClient := types.NewQueryClient(c.getQueryConn(options))
 
-	return f(ctx, queryClient)
+	return f(queryCtx, queryClient)
 }
 
-func (c *QueryClient) BNBHeader(number uint64) (*types.Header, error) {
+func (c *QueryClient) BNBHeader(number uint64, opts ...QueryOption) (*types.Header, error) {
+	return c.BNBHeaderWithContext(context.Background(), number, opts...)
+}
+
+// BNBHeaderWithContext is like BNBHeader but bounds the query by the given context
+func (c *QueryClient) BNBHeaderWithContext(ctx context.Context, number uint64, opts ...QueryOption) (*types.Header, error) {
 	var resp *types.QueryHeaderResponse
-	err := c.QueryBNBLightClient(func(ctx context.Context, queryClient types.QueryClient) error {
+	err := c.QueryBNBLightClientWithContext(ctx, func(ctx context.Context, queryClient types.QueryClient) error {
 		var err error
 		req := &types.QueryHeaderRequest{
 			Number: number,
 		}
 		resp, err = queryClient.Header(ctx, req)
 		return err
-	})
+	}, opts...)
 
 	if err != nil {
 		return nil, err
@@ -36,16 +45,21 @@ func (c *QueryClient) BNBHeader(number uint64) (*types.Header, error) {
 	return resp.Header, err
 }
 
-func (c *QueryClient) BNBHeaderByHash(hash string) (*types.Header, error) {
+func (c *QueryClient) BNBHeaderByHash(hash string, opts ...QueryOption) (*types.Header, error) {
+	return c.BNBHeaderByHashWithContext(context.Background(), hash, opts...)
+}
+
+// BNBHeaderByHashWithContext is like BNBHeaderByHash but bounds the query by the given context
+func (c *QueryClient) BNBHeaderByHashWithContext(ctx context.Context, hash string, opts ...QueryOption) (*types.Header, error) {
 	var resp *types.QueryHeaderByHashResponse
-	err := c.QueryBNBLightClient(func(ctx context.Context, queryClient types.QueryClient) error {
+	err := c.QueryBNBLightClientWithContext(ctx, func(ctx context.Context, queryClient types.QueryClient) error {
 		var err error
 		req := &types.QueryHeaderByHashRequest{
 			Hash: common.FromHex(hash),
 		}
 		resp, err = queryClient.HeaderByHash(ctx, req)
 		return err
-	})
+	}, opts...)
 
 	if err != nil {
 		return nil, err
@@ -54,14 +68,19 @@ func (c *QueryClient) BNBHeaderByHash(hash string) (*types.Header, error) {
 	return resp.Header, err
 }
 
-func (c *QueryClient) BNBLatestHeader() (*types.Header, error) {
+func (c *QueryClient) BNBLatestHeader(opts ...QueryOption) (*types.Header, error) {
+	return c.BNBLatestHeaderWithContext(context.Background(), opts...)
+}
+
+// BNBLatestHeaderWithContext is like BNBLatestHeader but bounds the query by the given context
+func (c *QueryClient) BNBLatestHeaderWithContext(ctx context.Context, opts ...QueryOption) (*types.Header, error) {
 	var resp *types.QueryLatestHeaderResponse
-	err := c.QueryBNBLightClient(func(ctx context.Context, queryClient types.QueryClient) error {
+	err := c.QueryBNBLightClientWithContext(ctx, func(ctx context.Context, queryClient types.QueryClient) error {
 		var err error
 		req := &types.QueryLatestHeaderRequest{}
 		resp, err = queryClient.LatestHeader(ctx, req)
 		return err
-	})
+	}, opts...)
 
 	if err != nil {
 		return nil, err
@@ -70,9 +89,14 @@ func (c *QueryClient) BNBLatestHeader() (*types.Header, error) {
 	return &resp.Header, err
 }
 
-func (c *QueryClient) BNBLightClientParams() (*types.QueryParamsResponse, error) {
+func (c *QueryClient) BNBLightClientParams(opts ...QueryOption) (*types.QueryParamsResponse, error) {
+	return c.BNBLightClientParamsWithContext(context.Background(), opts...)
+}
+
+// BNBLightClientParamsWithContext is like BNBLightClientParams but bounds the query by the given context
+func (c *QueryClient) BNBLightClientParamsWithContext(ctx context.Context, opts ...QueryOption) (*types.QueryParamsResponse, error) {
 	var resp *types.QueryParamsResponse
-	err := c.QueryBNBLightClient(func(ctx context.Context, queryClient types.QueryClient) error {
+	err := c.QueryBNBLightClientWithContext(ctx, func(ctx context.Context, queryClient types.QueryClient) error {
 		req := &types.QueryParamsRequest{}
 
 		var err error
@@ -82,7 +106,7 @@ func (c *QueryClient) BNBLightClientParams() (*types.QueryParamsResponse, error)
 		}
 
 		return nil
-	})
+	}, opts...)
 
 	return resp, err
 }
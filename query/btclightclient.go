@@ -5,74 +5,133 @@ import (
 
 	btclctypes "github.com/Lorenzo-Protocol/lorenzo/v3/x/btclightclient/types"
 	"github.com/btcsuite/btcd/chaincfg/chainhash"
-	"github.com/cosmos/cosmos-sdk/client"
 	sdkquerytypes "github.com/cosmos/cosmos-sdk/types/query"
 )
 
 // QueryBTCLightclient queries the BTCLightclient module of the Lorenzo node
 // according to the given function
-func (c *QueryClient) QueryBTCLightclient(f func(ctx context.Context, queryClient btclctypes.QueryClient) error) error {
-	ctx, cancel := c.getQueryContext()
+func (c *QueryClient) QueryBTCLightclient(f func(ctx context.Context, queryClient btclctypes.QueryClient) error, opts ...QueryOption) error {
+	return c.QueryBTCLightclientWithContext(context.Background(), f, opts...)
+}
+
+// QueryBTCLightclientWithContext is like QueryBTCLightclient but bounds the query by the given context
+func (c *QueryClient) QueryBTCLightclientWithContext(ctx context.Context, f func(ctx context.Context, queryClient btclctypes.QueryClient) error, opts ...QueryOption) error {
+	options := newQueryOptions(opts...)
+	queryCtx, cancel := c.getQueryContext(ctx, options)
 	defer cancel()
 
-	clientCtx := client.Context{Client: c.RPCClient}
-	queryClient := btclctypes.NewQueryClient(clientCtx)
+	queryClient := btclctypes.NewQueryClient(c.getQueryConn(options))
 
-	return f(ctx, queryClient)
+	return f(queryCtx, queryClient)
 }
 
 // BTCHeaderChainTip queries hash/height of the latest BTC block in the btclightclient module
-func (c *QueryClient) BTCHeaderChainTip() (*btclctypes.QueryTipResponse, error) {
+func (c *QueryClient) BTCHeaderChainTip(opts ...QueryOption) (*btclctypes.QueryTipResponse, error) {
+	return c.BTCHeaderChainTipWithContext(context.Background(), opts...)
+}
+
+// BTCHeaderChainTipWithContext is like BTCHeaderChainTip but bounds the query by the given context
+func (c *QueryClient) BTCHeaderChainTipWithContext(ctx context.Context, opts ...QueryOption) (*btclctypes.QueryTipResponse, error) {
 	var resp *btclctypes.QueryTipResponse
-	err := c.QueryBTCLightclient(func(ctx context.Context, queryClient btclctypes.QueryClient) error {
+	err := c.QueryBTCLightclientWithContext(ctx, func(ctx context.Context, queryClient btclctypes.QueryClient) error {
 		var err error
 		req := &btclctypes.QueryTipRequest{}
 		resp, err = queryClient.Tip(ctx, req)
 		return err
-	})
+	}, opts...)
 
 	return resp, err
 }
 
 // BTCBaseHeader queries the base BTC header of the btclightclient module
-func (c *QueryClient) BTCBaseHeader() (*btclctypes.QueryBaseHeaderResponse, error) {
+func (c *QueryClient) BTCBaseHeader(opts ...QueryOption) (*btclctypes.QueryBaseHeaderResponse, error) {
+	return c.BTCBaseHeaderWithContext(context.Background(), opts...)
+}
+
+// BTCBaseHeaderWithContext is like BTCBaseHeader but bounds the query by the given context
+func (c *QueryClient) BTCBaseHeaderWithContext(ctx context.Context, opts ...QueryOption) (*btclctypes.QueryBaseHeaderResponse, error) {
 	var resp *btclctypes.QueryBaseHeaderResponse
-	err := c.QueryBTCLightclient(func(ctx context.Context, queryClient btclctypes.QueryClient) error {
+	err := c.QueryBTCLightclientWithContext(ctx, func(ctx context.Context, queryClient btclctypes.QueryClient) error {
 		var err error
 		req := &btclctypes.QueryBaseHeaderRequest{}
 		resp, err = queryClient.BaseHeader(ctx, req)
 		return err
-	})
+	}, opts...)
 
 	return resp, err
 }
 
 // ContainsBTCBlock queries the btclightclient module for the existence of a block hash
-func (c *QueryClient) ContainsBTCBlock(blockHash *chainhash.Hash) (*btclctypes.QueryContainsBytesResponse, error) {
+func (c *QueryClient) ContainsBTCBlock(blockHash *chainhash.Hash, opts ...QueryOption) (*btclctypes.QueryContainsBytesResponse, error) {
+	return c.ContainsBTCBlockWithContext(context.Background(), blockHash, opts...)
+}
+
+// ContainsBTCBlockWithContext is like ContainsBTCBlock but bounds the query by the given context
+func (c *QueryClient) ContainsBTCBlockWithContext(ctx context.Context, blockHash *chainhash.Hash, opts ...QueryOption) (*btclctypes.QueryContainsBytesResponse, error) {
 	var resp *btclctypes.QueryContainsBytesResponse
-	err := c.QueryBTCLightclient(func(ctx context.Context, queryClient btclctypes.QueryClient) error {
+	err := c.QueryBTCLightclientWithContext(ctx, func(ctx context.Context, queryClient btclctypes.QueryClient) error {
 		var err error
 		req := &btclctypes.QueryContainsBytesRequest{
 			Hash: blockHash.CloneBytes(),
 		}
 		resp, err = queryClient.ContainsBytes(ctx, req)
 		return err
-	})
+	}, opts...)
 
 	return resp, err
 }
 
 // BTCMainChain queries the btclightclient module for the BTC canonical chain
-func (c *QueryClient) BTCMainChain(pagination *sdkquerytypes.PageRequest) (*btclctypes.QueryMainChainResponse, error) {
+func (c *QueryClient) BTCMainChain(pagination *sdkquerytypes.PageRequest, opts ...QueryOption) (*btclctypes.QueryMainChainResponse, error) {
+	return c.BTCMainChainWithContext(context.Background(), pagination, opts...)
+}
+
+// BTCMainChainWithContext is like BTCMainChain but bounds the query by the given context
+func (c *QueryClient) BTCMainChainWithContext(ctx context.Context, pagination *sdkquerytypes.PageRequest, opts ...QueryOption) (*btclctypes.QueryMainChainResponse, error) {
 	var resp *btclctypes.QueryMainChainResponse
-	err := c.QueryBTCLightclient(func(ctx context.Context, queryClient btclctypes.QueryClient) error {
+	err := c.QueryBTCLightclientWithContext(ctx, func(ctx context.Context, queryClient btclctypes.QueryClient) error {
 		var err error
 		req := &btclctypes.QueryMainChainRequest{
 			Pagination: pagination,
 		}
 		resp, err = queryClient.MainChain(ctx, req)
 		return err
-	})
+	}, opts...)
 
 	return resp, err
 }
+
+// WalkBTCMainChain calls f for every header of the BTC canonical chain, from the tip
+// down to the header at minHeight included, fetching the headers page by page
+func (c *QueryClient) WalkBTCMainChain(ctx context.Context, minHeight uint64, f func(header *btclctypes.BTCHeaderInfo) error, opts ...QueryOption) error {
+	return c.paginate(ctx, func(ctx context.Context, pageReq *sdkquerytypes.PageRequest, opts ...QueryOption) ([]byte, error) {
+		resp, err := c.BTCMainChainWithContext(ctx, pageReq, opts...)
+		if err != nil {
+			return nil, err
+		}
+		for _, header := range resp.Headers {
+			if header.Height < minHeight {
+				return nil, ErrStopWalk
+			}
+			if err := f(header); err != nil {
+				return nil, err
+			}
+		}
+		return nextKey(resp.Pagination), nil
+	}, opts...)
+}
+
+// BTCMainChainDownTo returns the headers of the BTC canonical chain from the tip
+// down to the header at minHeight included
+func (c *QueryClient) BTCMainChainDownTo(ctx context.Context, minHeight uint64, opts ...QueryOption) ([]*btclctypes.BTCHeaderInfo, error) {
+	var headers []*btclctypes.BTCHeaderInfo
+	err := c.WalkBTCMainChain(ctx, minHeight, func(header *btclctypes.BTCHeaderInfo) error {
+		headers = append(headers, header)
+		return nil
+	}, opts...)
+
+	if err != nil {
+		return nil, err
+	}
+	return headers, nil
+}
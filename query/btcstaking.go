@@ -5,22 +5,31 @@ import (
 
 	"github.com/Lorenzo-Protocol/lorenzo/v3/x/btcstaking/types"
 	"github.com/btcsuite/btcd/chaincfg/chainhash"
-	"github.com/cosmos/cosmos-sdk/client"
 )
 
-func (c *QueryClient) QueryBTCStaking(f func(ctx context.Context, queryClient types.QueryClient) error) error {
-	ctx, cancel := c.getQueryContext()
+func (c *QueryClient) QueryBTCStaking(f func(ctx context.Context, queryClient types.QueryClient) error, opts ...QueryOption) error {
+	return c.QueryBTCStakingWithContext(context.Background(), f, opts...)
+}
+
+// QueryBTCStakingWithContext is like QueryBTCStaking but bounds the query by the given context
+func (c *QueryClient) QueryBTCStakingWithContext(ctx context.Context, f func(ctx context.Context, queryClient types.QueryClient) error, opts ...QueryOption) error {
+	options := newQueryOptions(opts...)
+	queryCtx, cancel := c.getQueryContext(ctx, options)
 	defer cancel()
 
-	clientCtx := client.Context{Client: c.RPCClient}
-	queryClient := types.NewQueryClient(clientCtx)
+	queryClient := types.NewQueryClient(c.getQueryConn(options))
+
+	return f(queryCtx, queryClient)
+}
 
-	return f(ctx, queryClient)
+func (c *QueryClient) QueryBTCStakingParams(opts ...QueryOption) (*types.QueryParamsResponse, error) {
+	return c.QueryBTCStakingParamsWithContext(context.Background(), opts...)
 }
 
-func (c *QueryClient) QueryBTCStakingParams() (*types.QueryParamsResponse, error) {
+// QueryBTCStakingParamsWithContext is like QueryBTCStakingParams but bounds the query by the given context
+func (c *QueryClient) QueryBTCStakingParamsWithContext(ctx context.Context, opts ...QueryOption) (*types.QueryParamsResponse, error) {
 	var resp *types.QueryParamsResponse
-	err := c.QueryBTCStaking(func(ctx context.Context, queryClient types.QueryClient) error {
+	err := c.QueryBTCStakingWithContext(ctx, func(ctx context.Context, queryClient types.QueryClient) error {
 		req := &types.QueryParamsRequest{}
 
 		var err error
@@ -30,7 +39,7 @@ func (c *QueryClient) QueryBTCStakingParams() (*types.QueryParamsResponse, error
 		}
 
 		return nil
-	})
+	}, opts...)
 
 	if err != nil {
 		return nil, err
@@ -39,14 +48,19 @@ func (c *QueryClient) QueryBTCStakingParams() (*types.QueryParamsResponse, error
 	return resp, nil
 }
 
-func (c *QueryClient) GetBTCStakingRecord(txHash string) (*types.QueryStakingRecordResponse, error) {
+func (c *QueryClient) GetBTCStakingRecord(txHash string, opts ...QueryOption) (*types.QueryStakingRecordResponse, error) {
+	return c.GetBTCStakingRecordWithContext(context.Background(), txHash, opts...)
+}
+
+// GetBTCStakingRecordWithContext is like GetBTCStakingRecord but bounds the query by the given context
+func (c *QueryClient) GetBTCStakingRecordWithContext(ctx context.Context, txHash string, opts ...QueryOption) (*types.QueryStakingRecordResponse, error) {
 	txHashBytes, err := chainhash.NewHashFromStr(txHash)
 	if err != nil {
 		return nil, err
 	}
 
 	var resp *types.QueryStakingRecordResponse
-	err = c.QueryBTCStaking(func(ctx context.Context, queryClient types.QueryClient) error {
+	err = c.QueryBTCStakingWithContext(ctx, func(ctx context.Context, queryClient types.QueryClient) error {
 		req := &types.QueryStakingRecordRequest{
 			TxHash: txHashBytes[:],
 		}
@@ -54,7 +68,7 @@ func (c *QueryClient) GetBTCStakingRecord(txHash string) (*types.QueryStakingRec
 		var err error
 		resp, err = queryClient.StakingRecord(ctx, req)
 		return err
-	})
+	}, opts...)
 
 	return resp, err
 }
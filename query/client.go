@@ -10,6 +10,8 @@ import (
 	"github.com/cosmos/cosmos-sdk/client"
 	grpctypes "github.com/cosmos/cosmos-sdk/types/grpc"
 	"github.com/cosmos/cosmos-sdk/types/query"
+	gogogrpc "github.com/cosmos/gogoproto/grpc"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/metadata"
 
 	"github.com/Lorenzo-Protocol/lorenzo-sdk/v3/config"
@@ -18,8 +20,11 @@ import (
 // QueryClient is a client that can only perform queries to a Lorenzo node
 // It only requires `Cfg` to have `Timeout` and `RPCAddr`, but not other fields
 // such as keyring, chain ID, etc..
+// Module queries go through gRPC if a gRPC connection is given,
+// and through ABCI queries over the RPC client otherwise.
 type QueryClient struct {
 	RPCClient rpcclient.Client
+	GRPCConn  *grpc.ClientConn
 	timeout   time.Duration
 }
 
@@ -29,13 +34,39 @@ func New(cfg *config.LorenzoQueryConfig) (*QueryClient, error) {
 		return nil, err
 	}
 
-	tmClient, err := client.NewClientFromNode(cfg.RPCAddr)
+	var (
+		tmClient rpcclient.Client
+		err      error
+	)
+	endpoints := cfg.RPCEndpoints()
+	if len(endpoints) > 1 {
+		tmClient, err = NewEndpointPool(endpoints, cfg.Timeout, EndpointPoolOptions{
+			Selection:           cfg.RPCSelection,
+			MaxHeightLag:        cfg.RPCMaxHeightLag,
+			HealthCheckInterval: cfg.RPCHealthCheckInterval,
+		})
+	} else {
+		rpcAddr := cfg.RPCAddr
+		if len(endpoints) == 1 {
+			rpcAddr = endpoints[0]
+		}
+		tmClient, err = client.NewClientFromNode(rpcAddr)
+	}
 	if err != nil {
 		return nil, err
 	}
 
+	var grpcConn *grpc.ClientConn
+	if cfg.GRPCAddr != "" {
+		grpcConn, err = DialGRPC(cfg.GRPCAddr, cfg.GRPCTLS)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return &QueryClient{
 		RPCClient: tmClient,
+		GRPCConn:  grpcConn,
 		timeout:   cfg.Timeout,
 	}, nil
 }
@@ -43,23 +74,44 @@ func New(cfg *config.LorenzoQueryConfig) (*QueryClient, error) {
 // NewWithClient creates a new QueryClient with a given existing rpcClient and timeout
 // used by `client/` where `ChainClient` already creates an rpc client
 func NewWithClient(rpcClient rpcclient.Client, timeout time.Duration) (*QueryClient, error) {
+	return NewWithClients(rpcClient, nil, timeout)
+}
+
+// NewWithClients creates a new QueryClient with a given existing rpcClient, gRPC connection and timeout.
+// grpcConn may be nil, in which case module queries are sent as ABCI queries over rpcClient.
+func NewWithClients(rpcClient rpcclient.Client, grpcConn *grpc.ClientConn, timeout time.Duration) (*QueryClient, error) {
 	if timeout <= 0 {
 		return nil, fmt.Errorf("timeout must be positive")
 	}
 
 	client := &QueryClient{
 		RPCClient: rpcClient,
+		GRPCConn:  grpcConn,
 		timeout:   timeout,
 	}
 
 	return client, nil
 }
 
+// ActiveEndpoint returns the address of the RPC endpoint in use, which
+// changes over time if the client fails over between several endpoints
+func (c *QueryClient) ActiveEndpoint() string {
+	if remote, ok := c.RPCClient.(rpcclient.RemoteClient); ok {
+		return remote.Remote()
+	}
+	return ""
+}
+
 func (c *QueryClient) Start() error {
 	return c.RPCClient.Start()
 }
 
 func (c *QueryClient) Stop() error {
+	if c.GRPCConn != nil {
+		if err := c.GRPCConn.Close(); err != nil {
+			return err
+		}
+	}
 	return c.RPCClient.Stop()
 }
 
@@ -67,19 +119,110 @@ func (c *QueryClient) IsRunning() bool {
 	return c.RPCClient.IsRunning()
 }
 
-// getQueryContext returns a context that includes the height and uses the timeout from the config
+// getQueryContext returns a context derived from ctx that includes the height and uses the timeout
+// from the config, so the query ends at whichever of ctx's deadline and the timeout comes first
 // (adapted from https://github.com/strangelove-ventures/lens/blob/v0.5.4/client/query/query_options.go#L29-L36)
-func (c *QueryClient) getQueryContext() (context.Context, context.CancelFunc) {
-	defaultOptions := DefaultQueryOptions()
-	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
-	strHeight := strconv.Itoa(int(defaultOptions.Height))
+func (c *QueryClient) getQueryContext(ctx context.Context, options *QueryOptions) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	strHeight := strconv.Itoa(int(options.Height))
 	ctx = metadata.AppendToOutgoingContext(ctx, grpctypes.GRPCBlockHeightHeader, strHeight)
 	return ctx, cancel
 }
 
+// getQueryConn returns the connection used by the module query clients.
+// If the options ask for the response height, the connection records the
+// height reported by the node for every call made through it.
+func (c *QueryClient) getQueryConn(options *QueryOptions) gogogrpc.ClientConn {
+	var conn gogogrpc.ClientConn = client.Context{Client: c.RPCClient}
+	if c.GRPCConn != nil {
+		conn = c.GRPCConn
+	}
+	if options.ResponseHeight != nil {
+		conn = &heightRecordingConn{ClientConn: conn, height: options.ResponseHeight}
+	}
+	return conn
+}
+
+// heightRecordingConn wraps a gRPC client connection and stores the block height
+// a query was served at, as reported in the `x-cosmos-block-height` response header
+type heightRecordingConn struct {
+	gogogrpc.ClientConn
+	height *int64
+}
+
+func (c *heightRecordingConn) Invoke(ctx context.Context, method string, args, reply interface{}, opts ...grpc.CallOption) error {
+	var header metadata.MD
+	opts = append(opts, grpc.Header(&header))
+	if err := c.ClientConn.Invoke(ctx, method, args, reply, opts...); err != nil {
+		return err
+	}
+
+	heights := header.Get(grpctypes.GRPCBlockHeightHeader)
+	if len(heights) == 0 {
+		return fmt.Errorf("response is missing the %s header", grpctypes.GRPCBlockHeightHeader)
+	}
+	height, err := strconv.ParseInt(heights[0], 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid %s header: %w", grpctypes.GRPCBlockHeightHeader, err)
+	}
+	*c.height = height
+
+	return nil
+}
+
 type QueryOptions struct {
 	Pagination *query.PageRequest
 	Height     int64
+	// ResponseHeight, if set, receives the height the query was served at
+	ResponseHeight *int64
+	// PinHeight makes paginated walks query every page at the height of the first one
+	PinHeight bool
+}
+
+// QueryOption overrides a field of the QueryOptions used by a single query
+type QueryOption func(*QueryOptions)
+
+// WithHeight makes the query read the state at the given block height.
+// A height of 0 queries the latest state.
+func WithHeight(height int64) QueryOption {
+	return func(o *QueryOptions) {
+		o.Height = height
+	}
+}
+
+// WithResponseHeight stores the block height the query was actually served at into height
+func WithResponseHeight(height *int64) QueryOption {
+	return func(o *QueryOptions) {
+		o.ResponseHeight = height
+	}
+}
+
+// WithPagination sets the page request used by list queries.
+// Paginated walks start at its key and use its limit as page size.
+func WithPagination(pageRequest *query.PageRequest) QueryOption {
+	return func(o *QueryOptions) {
+		o.Pagination = pageRequest
+	}
+}
+
+// WithPageSize sets the number of items requested per page by paginated walks
+func WithPageSize(limit uint64) QueryOption {
+	return func(o *QueryOptions) {
+		pagination := query.PageRequest{}
+		if o.Pagination != nil {
+			pagination = *o.Pagination
+		}
+		pagination.Limit = limit
+		o.Pagination = &pagination
+	}
+}
+
+// WithPinnedHeight makes paginated walks read every page at the same block height,
+// so that the result is a consistent snapshot of the state
+func WithPinnedHeight() QueryOption {
+	return func(o *QueryOptions) {
+		o.PinHeight = true
+	}
 }
 
 func DefaultQueryOptions() *QueryOptions {
@@ -93,3 +236,12 @@ func DefaultQueryOptions() *QueryOptions {
 		Height: 0,
 	}
 }
+
+// newQueryOptions applies the given options on top of DefaultQueryOptions
+func newQueryOptions(opts ...QueryOption) *QueryOptions {
+	options := DefaultQueryOptions()
+	for _, opt := range opts {
+		opt(options)
+	}
+	return options
+}
@@ -0,0 +1,91 @@
+package query
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	plantypes "github.com/Lorenzo-Protocol/lorenzo/v3/x/plan/types"
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/libs/bytes"
+	rpcclient "github.com/cometbft/cometbft/rpc/client"
+	coretypes "github.com/cometbft/cometbft/rpc/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeABCINode serves the plan params through ABCI queries at the requested height,
+// or at its latest height if none is requested
+type fakeABCINode struct {
+	rpcclient.Client
+	latest    int64
+	requested []int64
+}
+
+func (f *fakeABCINode) ABCIQueryWithOptions(_ context.Context, path string, _ bytes.HexBytes, opts rpcclient.ABCIQueryOptions) (*coretypes.ResultABCIQuery, error) {
+	if path != "/lorenzo.plan.v1.Query/Params" {
+		return nil, fmt.Errorf("unexpected query %s", path)
+	}
+	f.requested = append(f.requested, opts.Height)
+
+	height := opts.Height
+	if height == 0 {
+		height = f.latest
+	}
+	bz, err := (&plantypes.QueryParamsResponse{}).Marshal()
+	if err != nil {
+		return nil, err
+	}
+	return &coretypes.ResultABCIQuery{Response: abci.ResponseQuery{Value: bz, Height: height}}, nil
+}
+
+func TestQueryHeight(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		opts      []QueryOption
+		requested int64
+		served    int64
+	}{
+		{"latest state", nil, 0, 100},
+		{"historical state", []QueryOption{WithHeight(42)}, 42, 42},
+		{"latest height given as 0", []QueryOption{WithHeight(0)}, 0, 100},
+		{"last height option wins", []QueryOption{WithHeight(42), WithHeight(7)}, 7, 7},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			node := &fakeABCINode{latest: 100}
+			c, err := NewWithClient(node, time.Second)
+			require.NoError(t, err)
+
+			// the height the query was served at is only reported on request
+			_, err = c.PlanParams(tc.opts...)
+			require.NoError(t, err)
+
+			var served int64
+			_, err = c.PlanParams(append(tc.opts, WithResponseHeight(&served))...)
+			require.NoError(t, err)
+			require.Equal(t, []int64{tc.requested, tc.requested}, node.requested)
+			require.Equal(t, tc.served, served)
+		})
+	}
+}
+
+func TestQueryOptions(t *testing.T) {
+	var served int64
+	for _, tc := range []struct {
+		name     string
+		opts     []QueryOption
+		expected func(*QueryOptions)
+	}{
+		{"defaults", nil, func(*QueryOptions) {}},
+		{"height", []QueryOption{WithHeight(5)}, func(o *QueryOptions) { o.Height = 5 }},
+		{"response height", []QueryOption{WithResponseHeight(&served)}, func(o *QueryOptions) { o.ResponseHeight = &served }},
+		{"pinned height", []QueryOption{WithPinnedHeight()}, func(o *QueryOptions) { o.PinHeight = true }},
+		{"page size", []QueryOption{WithPageSize(10)}, func(o *QueryOptions) { o.Pagination.Limit = 10 }},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			expected := DefaultQueryOptions()
+			tc.expected(expected)
+			require.Equal(t, expected, newQueryOptions(tc.opts...))
+		})
+	}
+}
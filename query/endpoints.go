@@ -0,0 +1,692 @@
+package query
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/cometbft/cometbft/libs/bytes"
+	"github.com/cometbft/cometbft/libs/service"
+	rpcclient "github.com/cometbft/cometbft/rpc/client"
+	rpchttp "github.com/cometbft/cometbft/rpc/client/http"
+	coretypes "github.com/cometbft/cometbft/rpc/core/types"
+	jsonrpcclient "github.com/cometbft/cometbft/rpc/jsonrpc/client"
+	rpctypes "github.com/cometbft/cometbft/rpc/jsonrpc/types"
+	"github.com/cometbft/cometbft/types"
+
+	"github.com/Lorenzo-Protocol/lorenzo-sdk/v3/config"
+)
+
+// Endpoint selection strategies for reads
+const (
+	SelectionRoundRobin    = config.RPCSelectionRoundRobin
+	SelectionLowestLatency = config.RPCSelectionLowestLatency
+)
+
+// SimulatePath is the ABCI query path simulating a tx. Simulations run against the mempool
+// state of the node, so the pool sends them to the endpoint txs are broadcast to.
+const SimulatePath = "/cosmos.tx.v1beta1.Service/Simulate"
+
+// DefaultHealthCheckInterval is the interval between two health probes of a running pool
+// whose options leave it unset
+const DefaultHealthCheckInterval = 30 * time.Second
+
+var _ rpcclient.RemoteClient = &EndpointPool{}
+
+// EndpointPoolOptions configures how an EndpointPool selects and probes its endpoints
+type EndpointPoolOptions struct {
+	// Selection is the strategy used to pick the endpoint serving a read,
+	// either SelectionRoundRobin (default) or SelectionLowestLatency
+	Selection string
+	// MaxHeightLag is the number of blocks an endpoint may be behind the highest
+	// endpoint before being considered unhealthy, 0 disables the check
+	MaxHeightLag int64
+	// HealthCheckInterval is the interval between two health probes of all endpoints
+	// while the pool is running, DefaultHealthCheckInterval if 0. The probes are what marks
+	// failed endpoints healthy again, so a negative interval disabling them should only be
+	// used along with explicit calls to Probe.
+	HealthCheckInterval time.Duration
+}
+
+// EndpointStatus is the last known state of an endpoint of an EndpointPool
+type EndpointStatus struct {
+	Addr       string
+	Healthy    bool
+	CatchingUp bool
+	Height     int64
+	Latency    time.Duration
+	LastError  error
+	CheckedAt  time.Time
+}
+
+type endpoint struct {
+	addr   string
+	client rpcclient.Client
+	status EndpointStatus
+}
+
+// EndpointPool is an RPC client spreading calls over several CometBFT RPC endpoints.
+// Reads are served by the healthy endpoints according to the selection strategy and
+// fail over to the next endpoint on transport errors. Broadcasts and subscriptions stick
+// to the active endpoint, which only changes when it fails or becomes unhealthy.
+// Endpoints are marked unhealthy on transport errors, and are probed through their
+// status, which reports whether they are catching up and their latest height.
+type EndpointPool struct {
+	*service.BaseService
+
+	opts      EndpointPoolOptions
+	endpoints []*endpoint
+	next      atomic.Uint64
+
+	mu            sync.RWMutex
+	active        int
+	subscriptions map[string]map[int]struct{}
+}
+
+// NewEndpointPool creates an EndpointPool over the RPC endpoints at the given addresses.
+// Every call to an endpoint is bounded by the given timeout.
+func NewEndpointPool(addrs []string, timeout time.Duration, opts EndpointPoolOptions) (*EndpointPool, error) {
+	clients := make([]rpcclient.Client, 0, len(addrs))
+	for _, addr := range addrs {
+		rpcClient, err := NewRPCClient(addr, timeout)
+		if err != nil {
+			return nil, err
+		}
+		clients = append(clients, rpcClient)
+	}
+
+	return NewEndpointPoolWithClients(addrs, clients, opts)
+}
+
+// NewRPCClient creates an RPC client of the endpoint at the given address, whose calls
+// are bounded by the given timeout
+func NewRPCClient(addr string, timeout time.Duration) (*rpchttp.HTTP, error) {
+	httpClient, err := jsonrpcclient.DefaultHTTPClient(addr)
+	if err != nil {
+		return nil, err
+	}
+	httpClient.Timeout = timeout
+
+	return rpchttp.NewWithClient(addr, "/websocket", httpClient)
+}
+
+// NewEndpointPoolWithClients creates an EndpointPool over existing RPC clients,
+// where addrs[i] is the address clients[i] is connected to
+func NewEndpointPoolWithClients(addrs []string, clients []rpcclient.Client, opts EndpointPoolOptions) (*EndpointPool, error) {
+	if len(addrs) == 0 {
+		return nil, fmt.Errorf("at least one endpoint is required")
+	}
+	if len(addrs) != len(clients) {
+		return nil, fmt.Errorf("got %d addresses for %d clients", len(addrs), len(clients))
+	}
+	if opts.HealthCheckInterval == 0 {
+		opts.HealthCheckInterval = DefaultHealthCheckInterval
+	}
+	switch opts.Selection {
+	case "":
+		opts.Selection = SelectionRoundRobin
+	case SelectionRoundRobin, SelectionLowestLatency:
+	default:
+		return nil, fmt.Errorf("unknown endpoint selection %q", opts.Selection)
+	}
+
+	p := &EndpointPool{
+		opts:          opts,
+		subscriptions: map[string]map[int]struct{}{},
+	}
+	for i, addr := range addrs {
+		p.endpoints = append(p.endpoints, &endpoint{
+			addr:   addr,
+			client: clients[i],
+			status: EndpointStatus{Addr: addr, Healthy: true},
+		})
+	}
+	p.BaseService = service.NewBaseService(nil, "EndpointPool", p)
+
+	return p, nil
+}
+
+// OnStart starts the clients of all endpoints and the periodic health probes. Endpoints
+// whose client fails to start, e.g. because the node is down, are marked unhealthy and
+// started again by the health probes, so that the pool starts even if no node is up yet.
+func (p *EndpointPool) OnStart() error {
+	p.startClients()
+
+	if p.opts.HealthCheckInterval > 0 {
+		ctx, cancel := context.WithTimeout(context.Background(), p.opts.HealthCheckInterval)
+		p.Probe(ctx)
+		cancel()
+
+		go p.healthCheckLoop()
+	}
+
+	return nil
+}
+
+// OnStop stops the clients of all endpoints
+func (p *EndpointPool) OnStop() {
+	for _, e := range p.endpoints {
+		if e.client.IsRunning() {
+			if err := e.client.Stop(); err != nil {
+				p.Logger.Error("failed to stop RPC endpoint", "addr", e.addr, "err", err)
+			}
+		}
+	}
+}
+
+func (p *EndpointPool) healthCheckLoop() {
+	ticker := time.NewTicker(p.opts.HealthCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			p.startClients()
+			ctx, cancel := context.WithTimeout(context.Background(), p.opts.HealthCheckInterval)
+			p.Probe(ctx)
+			cancel()
+		case <-p.Quit():
+			return
+		}
+	}
+}
+
+// startClients starts the clients of the endpoints that are not running, which the
+// subscriptions need, marking the ones that fail to start unhealthy
+func (p *EndpointPool) startClients() {
+	for _, e := range p.endpoints {
+		if e.client.IsRunning() {
+			continue
+		}
+		if err := e.client.Start(); err != nil {
+			p.Logger.Error("failed to start RPC endpoint", "addr", e.addr, "err", err)
+			p.markFailed(e, err)
+		}
+	}
+}
+
+// Probe queries the status of every endpoint and updates their health.
+// An endpoint is healthy if it answers, is not catching up and is at most
+// MaxHeightLag blocks behind the highest endpoint.
+func (p *EndpointPool) Probe(ctx context.Context) []EndpointStatus {
+	statuses := make([]EndpointStatus, len(p.endpoints))
+
+	var wg sync.WaitGroup
+	for i, e := range p.endpoints {
+		wg.Add(1)
+		go func(i int, e *endpoint) {
+			defer wg.Done()
+
+			start := time.Now()
+			res, err := e.client.Status(ctx)
+			statuses[i] = EndpointStatus{
+				Addr:      e.addr,
+				Latency:   time.Since(start),
+				LastError: err,
+				CheckedAt: time.Now(),
+			}
+			if err == nil {
+				statuses[i].CatchingUp = res.SyncInfo.CatchingUp
+				statuses[i].Height = res.SyncInfo.LatestBlockHeight
+			}
+		}(i, e)
+	}
+	wg.Wait()
+
+	var maxHeight int64
+	for _, status := range statuses {
+		if status.Height > maxHeight {
+			maxHeight = status.Height
+		}
+	}
+	for i := range statuses {
+		statuses[i].Healthy = statuses[i].LastError == nil && !statuses[i].CatchingUp &&
+			(p.opts.MaxHeightLag == 0 || maxHeight-statuses[i].Height <= p.opts.MaxHeightLag)
+	}
+
+	p.mu.Lock()
+	for i, e := range p.endpoints {
+		e.status = statuses[i]
+	}
+	p.mu.Unlock()
+
+	return statuses
+}
+
+// Endpoints returns the last known state of all endpoints
+func (p *EndpointPool) Endpoints() []EndpointStatus {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	statuses := make([]EndpointStatus, 0, len(p.endpoints))
+	for _, e := range p.endpoints {
+		statuses = append(statuses, e.status)
+	}
+	return statuses
+}
+
+// ActiveEndpoint returns the address of the endpoint used for broadcasts and subscriptions
+func (p *EndpointPool) ActiveEndpoint() string {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	return p.endpoints[p.active].addr
+}
+
+// Remote implements rpcclient.RemoteClient, returning the active endpoint
+func (p *EndpointPool) Remote() string {
+	return p.ActiveEndpoint()
+}
+
+// readOrder returns the indexes of the endpoints in the order they should serve a read:
+// healthy endpoints first, ordered by the selection strategy, then the unhealthy ones
+func (p *EndpointPool) readOrder() []int {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	var healthy, unhealthy []int
+	for i, e := range p.endpoints {
+		if e.status.Healthy {
+			healthy = append(healthy, i)
+		} else {
+			unhealthy = append(unhealthy, i)
+		}
+	}
+
+	switch p.opts.Selection {
+	case SelectionLowestLatency:
+		sort.SliceStable(healthy, func(a, b int) bool {
+			return p.endpoints[healthy[a]].status.Latency < p.endpoints[healthy[b]].status.Latency
+		})
+	default:
+		if len(healthy) > 0 {
+			shift := int(p.next.Add(1) % uint64(len(healthy)))
+			healthy = append(healthy[shift:], healthy[:shift]...)
+		}
+	}
+
+	return append(healthy, unhealthy...)
+}
+
+// stickyOrder returns the indexes of the endpoints in the order they should serve a broadcast:
+// the active endpoint first if it is healthy, then the others in read order
+func (p *EndpointPool) stickyOrder() []int {
+	p.mu.RLock()
+	active := p.active
+	activeHealthy := p.endpoints[active].status.Healthy
+	p.mu.RUnlock()
+
+	order := p.readOrder()
+	if !activeHealthy {
+		return order
+	}
+
+	sticky := []int{active}
+	for _, i := range order {
+		if i != active {
+			sticky = append(sticky, i)
+		}
+	}
+	return sticky
+}
+
+func (p *EndpointPool) markFailed(e *endpoint, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	e.status.Healthy = false
+	e.status.LastError = err
+}
+
+func (p *EndpointPool) setActive(i int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.active != i {
+		p.Logger.Info("switching active RPC endpoint",
+			"from", p.endpoints[p.active].addr, "to", p.endpoints[i].addr)
+		p.active = i
+	}
+}
+
+// isTransportError tells whether err comes from reaching the endpoint rather than from
+// the node rejecting the call, in which case the call can be tried on another endpoint
+func isTransportError(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	var rpcErr *rpctypes.RPCError
+	return !errors.As(err, &rpcErr)
+}
+
+// call runs f on the endpoints in the given order until one of them does not fail
+// with a transport error, and returns the index of that endpoint
+func (p *EndpointPool) call(ctx context.Context, order []int, f func(client rpcclient.Client) error) (int, error) {
+	var err error
+	for _, i := range order {
+		e := p.endpoints[i]
+		if err = f(e.client); err == nil || !isTransportError(ctx, err) {
+			return i, err
+		}
+		p.Logger.Error("RPC endpoint failed", "addr", e.addr, "err", err)
+		p.markFailed(e, err)
+	}
+	return -1, fmt.Errorf("all %d RPC endpoints failed: %w", len(order), err)
+}
+
+// read runs f on the endpoints selected for reads
+func (p *EndpointPool) read(ctx context.Context, f func(client rpcclient.Client) error) error {
+	_, err := p.call(ctx, p.readOrder(), f)
+	return err
+}
+
+// write runs f on the active endpoint, failing over to the next endpoint
+// which then becomes the active one
+func (p *EndpointPool) write(ctx context.Context, f func(client rpcclient.Client) error) (int, error) {
+	i, err := p.call(ctx, p.stickyOrder(), f)
+	if i >= 0 {
+		p.setActive(i)
+	}
+	return i, err
+}
+
+func (p *EndpointPool) ABCIInfo(ctx context.Context) (res *coretypes.ResultABCIInfo, err error) {
+	err = p.read(ctx, func(c rpcclient.Client) error {
+		res, err = c.ABCIInfo(ctx)
+		return err
+	})
+	return res, err
+}
+
+func (p *EndpointPool) ABCIQuery(ctx context.Context, path string, data bytes.HexBytes) (res *coretypes.ResultABCIQuery, err error) {
+	err = p.abciQuery(ctx, path, func(c rpcclient.Client) error {
+		res, err = c.ABCIQuery(ctx, path, data)
+		return err
+	})
+	return res, err
+}
+
+func (p *EndpointPool) ABCIQueryWithOptions(ctx context.Context, path string, data bytes.HexBytes, opts rpcclient.ABCIQueryOptions) (res *coretypes.ResultABCIQuery, err error) {
+	err = p.abciQuery(ctx, path, func(c rpcclient.Client) error {
+		res, err = c.ABCIQueryWithOptions(ctx, path, data, opts)
+		return err
+	})
+	return res, err
+}
+
+// abciQuery runs f as a read, unless it simulates a tx
+func (p *EndpointPool) abciQuery(ctx context.Context, path string, f func(client rpcclient.Client) error) error {
+	if path == SimulatePath {
+		_, err := p.write(ctx, f)
+		return err
+	}
+	return p.read(ctx, f)
+}
+
+func (p *EndpointPool) BroadcastTxCommit(ctx context.Context, tx types.Tx) (res *coretypes.ResultBroadcastTxCommit, err error) {
+	_, err = p.write(ctx, func(c rpcclient.Client) error {
+		res, err = c.BroadcastTxCommit(ctx, tx)
+		return err
+	})
+	return res, err
+}
+
+func (p *EndpointPool) BroadcastTxAsync(ctx context.Context, tx types.Tx) (res *coretypes.ResultBroadcastTx, err error) {
+	_, err = p.write(ctx, func(c rpcclient.Client) error {
+		res, err = c.BroadcastTxAsync(ctx, tx)
+		return err
+	})
+	return res, err
+}
+
+func (p *EndpointPool) BroadcastTxSync(ctx context.Context, tx types.Tx) (res *coretypes.ResultBroadcastTx, err error) {
+	_, err = p.write(ctx, func(c rpcclient.Client) error {
+		res, err = c.BroadcastTxSync(ctx, tx)
+		return err
+	})
+	return res, err
+}
+
+func (p *EndpointPool) Subscribe(ctx context.Context, subscriber, query string, outCapacity ...int) (out <-chan coretypes.ResultEvent, err error) {
+	i, err := p.write(ctx, func(c rpcclient.Client) error {
+		out, err = c.Subscribe(ctx, subscriber, query, outCapacity...)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	if p.subscriptions[subscriber] == nil {
+		p.subscriptions[subscriber] = map[int]struct{}{}
+	}
+	p.subscriptions[subscriber][i] = struct{}{}
+	p.mu.Unlock()
+
+	return out, nil
+}
+
+// subscribedEndpoints returns the indexes of the endpoints the subscriber has subscriptions on
+func (p *EndpointPool) subscribedEndpoints(subscriber string) []int {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	var indexes []int
+	for i := range p.subscriptions[subscriber] {
+		indexes = append(indexes, i)
+	}
+	return indexes
+}
+
+func (p *EndpointPool) Unsubscribe(ctx context.Context, subscriber, query string) error {
+	var lastErr error
+	for _, i := range p.subscribedEndpoints(subscriber) {
+		if err := p.endpoints[i].client.Unsubscribe(ctx, subscriber, query); err != nil {
+			lastErr = err
+		}
+	}
+	return lastErr
+}
+
+func (p *EndpointPool) UnsubscribeAll(ctx context.Context, subscriber string) error {
+	var lastErr error
+	for _, i := range p.subscribedEndpoints(subscriber) {
+		if err := p.endpoints[i].client.UnsubscribeAll(ctx, subscriber); err != nil {
+			lastErr = err
+		}
+	}
+
+	p.mu.Lock()
+	delete(p.subscriptions, subscriber)
+	p.mu.Unlock()
+
+	return lastErr
+}
+
+func (p *EndpointPool) Genesis(ctx context.Context) (res *coretypes.ResultGenesis, err error) {
+	err = p.read(ctx, func(c rpcclient.Client) error {
+		res, err = c.Genesis(ctx)
+		return err
+	})
+	return res, err
+}
+
+func (p *EndpointPool) GenesisChunked(ctx context.Context, id uint) (res *coretypes.ResultGenesisChunk, err error) {
+	err = p.read(ctx, func(c rpcclient.Client) error {
+		res, err = c.GenesisChunked(ctx, id)
+		return err
+	})
+	return res, err
+}
+
+func (p *EndpointPool) BlockchainInfo(ctx context.Context, minHeight, maxHeight int64) (res *coretypes.ResultBlockchainInfo, err error) {
+	err = p.read(ctx, func(c rpcclient.Client) error {
+		res, err = c.BlockchainInfo(ctx, minHeight, maxHeight)
+		return err
+	})
+	return res, err
+}
+
+func (p *EndpointPool) NetInfo(ctx context.Context) (res *coretypes.ResultNetInfo, err error) {
+	err = p.read(ctx, func(c rpcclient.Client) error {
+		res, err = c.NetInfo(ctx)
+		return err
+	})
+	return res, err
+}
+
+func (p *EndpointPool) DumpConsensusState(ctx context.Context) (res *coretypes.ResultDumpConsensusState, err error) {
+	err = p.read(ctx, func(c rpcclient.Client) error {
+		res, err = c.DumpConsensusState(ctx)
+		return err
+	})
+	return res, err
+}
+
+func (p *EndpointPool) ConsensusState(ctx context.Context) (res *coretypes.ResultConsensusState, err error) {
+	err = p.read(ctx, func(c rpcclient.Client) error {
+		res, err = c.ConsensusState(ctx)
+		return err
+	})
+	return res, err
+}
+
+func (p *EndpointPool) ConsensusParams(ctx context.Context, height *int64) (res *coretypes.ResultConsensusParams, err error) {
+	err = p.read(ctx, func(c rpcclient.Client) error {
+		res, err = c.ConsensusParams(ctx, height)
+		return err
+	})
+	return res, err
+}
+
+func (p *EndpointPool) Health(ctx context.Context) (res *coretypes.ResultHealth, err error) {
+	err = p.read(ctx, func(c rpcclient.Client) error {
+		res, err = c.Health(ctx)
+		return err
+	})
+	return res, err
+}
+
+func (p *EndpointPool) Block(ctx context.Context, height *int64) (res *coretypes.ResultBlock, err error) {
+	err = p.read(ctx, func(c rpcclient.Client) error {
+		res, err = c.Block(ctx, height)
+		return err
+	})
+	return res, err
+}
+
+func (p *EndpointPool) BlockByHash(ctx context.Context, hash []byte) (res *coretypes.ResultBlock, err error) {
+	err = p.read(ctx, func(c rpcclient.Client) error {
+		res, err = c.BlockByHash(ctx, hash)
+		return err
+	})
+	return res, err
+}
+
+func (p *EndpointPool) BlockResults(ctx context.Context, height *int64) (res *coretypes.ResultBlockResults, err error) {
+	err = p.read(ctx, func(c rpcclient.Client) error {
+		res, err = c.BlockResults(ctx, height)
+		return err
+	})
+	return res, err
+}
+
+func (p *EndpointPool) Header(ctx context.Context, height *int64) (res *coretypes.ResultHeader, err error) {
+	err = p.read(ctx, func(c rpcclient.Client) error {
+		res, err = c.Header(ctx, height)
+		return err
+	})
+	return res, err
+}
+
+func (p *EndpointPool) HeaderByHash(ctx context.Context, hash bytes.HexBytes) (res *coretypes.ResultHeader, err error) {
+	err = p.read(ctx, func(c rpcclient.Client) error {
+		res, err = c.HeaderByHash(ctx, hash)
+		return err
+	})
+	return res, err
+}
+
+func (p *EndpointPool) Commit(ctx context.Context, height *int64) (res *coretypes.ResultCommit, err error) {
+	err = p.read(ctx, func(c rpcclient.Client) error {
+		res, err = c.Commit(ctx, height)
+		return err
+	})
+	return res, err
+}
+
+func (p *EndpointPool) Validators(ctx context.Context, height *int64, page, perPage *int) (res *coretypes.ResultValidators, err error) {
+	err = p.read(ctx, func(c rpcclient.Client) error {
+		res, err = c.Validators(ctx, height, page, perPage)
+		return err
+	})
+	return res, err
+}
+
+func (p *EndpointPool) Tx(ctx context.Context, hash []byte, prove bool) (res *coretypes.ResultTx, err error) {
+	err = p.read(ctx, func(c rpcclient.Client) error {
+		res, err = c.Tx(ctx, hash, prove)
+		return err
+	})
+	return res, err
+}
+
+func (p *EndpointPool) TxSearch(ctx context.Context, query string, prove bool, page, perPage *int, orderBy string) (res *coretypes.ResultTxSearch, err error) {
+	err = p.read(ctx, func(c rpcclient.Client) error {
+		res, err = c.TxSearch(ctx, query, prove, page, perPage, orderBy)
+		return err
+	})
+	return res, err
+}
+
+func (p *EndpointPool) BlockSearch(ctx context.Context, query string, page, perPage *int, orderBy string) (res *coretypes.ResultBlockSearch, err error) {
+	err = p.read(ctx, func(c rpcclient.Client) error {
+		res, err = c.BlockSearch(ctx, query, page, perPage, orderBy)
+		return err
+	})
+	return res, err
+}
+
+func (p *EndpointPool) Status(ctx context.Context) (res *coretypes.ResultStatus, err error) {
+	err = p.read(ctx, func(c rpcclient.Client) error {
+		res, err = c.Status(ctx)
+		return err
+	})
+	return res, err
+}
+
+func (p *EndpointPool) BroadcastEvidence(ctx context.Context, ev types.Evidence) (res *coretypes.ResultBroadcastEvidence, err error) {
+	_, err = p.write(ctx, func(c rpcclient.Client) error {
+		res, err = c.BroadcastEvidence(ctx, ev)
+		return err
+	})
+	return res, err
+}
+
+func (p *EndpointPool) UnconfirmedTxs(ctx context.Context, limit *int) (res *coretypes.ResultUnconfirmedTxs, err error) {
+	err = p.read(ctx, func(c rpcclient.Client) error {
+		res, err = c.UnconfirmedTxs(ctx, limit)
+		return err
+	})
+	return res, err
+}
+
+func (p *EndpointPool) NumUnconfirmedTxs(ctx context.Context) (res *coretypes.ResultUnconfirmedTxs, err error) {
+	err = p.read(ctx, func(c rpcclient.Client) error {
+		res, err = c.NumUnconfirmedTxs(ctx)
+		return err
+	})
+	return res, err
+}
+
+func (p *EndpointPool) CheckTx(ctx context.Context, tx types.Tx) (res *coretypes.ResultCheckTx, err error) {
+	err = p.read(ctx, func(c rpcclient.Client) error {
+		res, err = c.CheckTx(ctx, tx)
+		return err
+	})
+	return res, err
+}
@@ -0,0 +1,164 @@
+package query
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	rpcclient "github.com/cometbft/cometbft/rpc/client"
+	coretypes "github.com/cometbft/cometbft/rpc/core/types"
+	rpctypes "github.com/cometbft/cometbft/rpc/jsonrpc/types"
+	"github.com/cometbft/cometbft/types"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeEndpoint answers Status with its height and fails with err if set
+type fakeEndpoint struct {
+	rpcclient.Client
+	height     int64
+	catchingUp bool
+	err        error
+	calls      int
+
+	mu       sync.Mutex
+	running  bool
+	startErr error
+}
+
+func (f *fakeEndpoint) Start() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.startErr != nil {
+		return f.startErr
+	}
+	f.running = true
+	return nil
+}
+
+func (f *fakeEndpoint) Stop() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.running = false
+	return nil
+}
+
+func (f *fakeEndpoint) IsRunning() bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.running
+}
+
+func (f *fakeEndpoint) setErrs(err, startErr error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.err = err
+	f.startErr = startErr
+}
+
+func (f *fakeEndpoint) Status(context.Context) (*coretypes.ResultStatus, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls++
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &coretypes.ResultStatus{SyncInfo: coretypes.SyncInfo{
+		LatestBlockHeight: f.height,
+		CatchingUp:        f.catchingUp,
+	}}, nil
+}
+
+func (f *fakeEndpoint) BroadcastTxSync(context.Context, types.Tx) (*coretypes.ResultBroadcastTx, error) {
+	f.calls++
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &coretypes.ResultBroadcastTx{}, nil
+}
+
+func newFakePool(t *testing.T, opts EndpointPoolOptions, endpoints ...*fakeEndpoint) *EndpointPool {
+	addrs := []string{"http://a:26657", "http://b:26657", "http://c:26657"}[:len(endpoints)]
+	clients := make([]rpcclient.Client, len(endpoints))
+	for i, e := range endpoints {
+		clients[i] = e
+	}
+	pool, err := NewEndpointPoolWithClients(addrs, clients, opts)
+	require.NoError(t, err)
+	return pool
+}
+
+func TestEndpointPoolFailover(t *testing.T) {
+	a, b := &fakeEndpoint{height: 10, err: errors.New("connection refused")}, &fakeEndpoint{height: 10}
+	pool := newFakePool(t, EndpointPoolOptions{}, a, b)
+
+	// broadcasts move to the second endpoint and stick to it
+	_, err := pool.BroadcastTxSync(context.Background(), nil)
+	require.NoError(t, err)
+	require.Equal(t, "http://b:26657", pool.ActiveEndpoint())
+
+	a.err = nil
+	for i := 0; i < 3; i++ {
+		_, err = pool.BroadcastTxSync(context.Background(), nil)
+		require.NoError(t, err)
+	}
+	require.Equal(t, 1, a.calls)
+	require.Equal(t, 4, b.calls)
+
+	// errors returned by the node are not failed over
+	b.err = &rpctypes.RPCError{Code: -32603, Message: "Internal error"}
+	_, err = pool.BroadcastTxSync(context.Background(), nil)
+	require.Error(t, err)
+	require.Equal(t, 1, a.calls)
+	require.Equal(t, "http://b:26657", pool.ActiveEndpoint())
+}
+
+func TestEndpointPoolProbe(t *testing.T) {
+	a := &fakeEndpoint{height: 100}
+	b := &fakeEndpoint{height: 90}
+	c := &fakeEndpoint{height: 100, catchingUp: true}
+	pool := newFakePool(t, EndpointPoolOptions{MaxHeightLag: 5}, a, b, c)
+
+	statuses := pool.Probe(context.Background())
+	require.True(t, statuses[0].Healthy)
+	require.False(t, statuses[1].Healthy)
+	require.False(t, statuses[2].Healthy)
+
+	// reads only go to the healthy endpoint while it answers
+	a.calls = 0
+	for i := 0; i < 4; i++ {
+		_, err := pool.Status(context.Background())
+		require.NoError(t, err)
+	}
+	require.Equal(t, 4, a.calls)
+}
+
+func TestEndpointPoolRecovery(t *testing.T) {
+	down := errors.New("connection refused")
+	a := &fakeEndpoint{height: 10, err: down, startErr: down}
+	b := &fakeEndpoint{height: 10}
+	pool := newFakePool(t, EndpointPoolOptions{HealthCheckInterval: 10 * time.Millisecond}, a, b)
+
+	// the pool starts even though a node is down, which is marked unhealthy
+	require.NoError(t, pool.Start())
+	require.False(t, a.IsRunning())
+	require.True(t, b.IsRunning())
+	require.False(t, pool.Endpoints()[0].Healthy)
+
+	// the health probes start its client and mark it healthy once it is back
+	a.setErrs(nil, nil)
+	require.Eventually(t, func() bool {
+		return a.IsRunning() && pool.Endpoints()[0].Healthy
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// stopping the pool stops the clients
+	require.NoError(t, pool.Stop())
+	require.False(t, a.IsRunning())
+	require.False(t, b.IsRunning())
+}
+
+func TestEndpointPoolDefaultHealthCheckInterval(t *testing.T) {
+	pool := newFakePool(t, EndpointPoolOptions{}, &fakeEndpoint{})
+	require.Equal(t, DefaultHealthCheckInterval, pool.opts.HealthCheckInterval)
+}
@@ -0,0 +1,101 @@
+package query
+
+import (
+	"context"
+
+	"github.com/cosmos/cosmos-sdk/types/query"
+	"github.com/cosmos/cosmos-sdk/x/feegrant"
+)
+
+// QueryFeeGrant queries the FeeGrant module of the Lorenzo node
+// according to the given function
+func (c *QueryClient) QueryFeeGrant(f func(ctx context.Context, queryClient feegrant.QueryClient) error, opts ...QueryOption) error {
+	return c.QueryFeeGrantWithContext(context.Background(), f, opts...)
+}
+
+// QueryFeeGrantWithContext is like QueryFeeGrant but bounds the query by the given context
+func (c *QueryClient) QueryFeeGrantWithContext(ctx context.Context, f func(ctx context.Context, queryClient feegrant.QueryClient) error, opts ...QueryOption) error {
+	options := newQueryOptions(opts...)
+	queryCtx, cancel := c.getQueryContext(ctx, options)
+	defer cancel()
+
+	queryClient := feegrant.NewQueryClient(c.getQueryConn(options))
+
+	return f(queryCtx, queryClient)
+}
+
+// FeeAllowance queries the fee allowance granter gives to grantee
+func (c *QueryClient) FeeAllowance(granter, grantee string, opts ...QueryOption) (*feegrant.QueryAllowanceResponse, error) {
+	return c.FeeAllowanceWithContext(context.Background(), granter, grantee, opts...)
+}
+
+// FeeAllowanceWithContext is like FeeAllowance but bounds the query by the given context
+func (c *QueryClient) FeeAllowanceWithContext(ctx context.Context, granter, grantee string, opts ...QueryOption) (*feegrant.QueryAllowanceResponse, error) {
+	var resp *feegrant.QueryAllowanceResponse
+	err := c.QueryFeeGrantWithContext(ctx, func(ctx context.Context, queryClient feegrant.QueryClient) error {
+		var err error
+		resp, err = queryClient.Allowance(ctx, &feegrant.QueryAllowanceRequest{
+			Granter: granter,
+			Grantee: grantee,
+		})
+		return err
+	}, opts...)
+
+	return resp, err
+}
+
+// FeeAllowances queries the fee allowances given to grantee
+func (c *QueryClient) FeeAllowances(grantee string, pageRequest *query.PageRequest, opts ...QueryOption) (*feegrant.QueryAllowancesResponse, error) {
+	return c.FeeAllowancesWithContext(context.Background(), grantee, pageRequest, opts...)
+}
+
+// FeeAllowancesWithContext is like FeeAllowances but bounds the query by the given context
+func (c *QueryClient) FeeAllowancesWithContext(ctx context.Context, grantee string, pageRequest *query.PageRequest, opts ...QueryOption) (*feegrant.QueryAllowancesResponse, error) {
+	var resp *feegrant.QueryAllowancesResponse
+	err := c.QueryFeeGrantWithContext(ctx, func(ctx context.Context, queryClient feegrant.QueryClient) error {
+		var err error
+		resp, err = queryClient.Allowances(ctx, &feegrant.QueryAllowancesRequest{
+			Grantee:    grantee,
+			Pagination: pageRequest,
+		})
+		return err
+	}, opts...)
+
+	return resp, err
+}
+
+// FeeAllowancesByGranter queries the fee allowances given by granter
+func (c *QueryClient) FeeAllowancesByGranter(granter string, pageRequest *query.PageRequest, opts ...QueryOption) (*feegrant.QueryAllowancesByGranterResponse, error) {
+	return c.FeeAllowancesByGranterWithContext(context.Background(), granter, pageRequest, opts...)
+}
+
+// FeeAllowancesByGranterWithContext is like FeeAllowancesByGranter but bounds the query by the given context
+func (c *QueryClient) FeeAllowancesByGranterWithContext(ctx context.Context, granter string, pageRequest *query.PageRequest, opts ...QueryOption) (*feegrant.QueryAllowancesByGranterResponse, error) {
+	var resp *feegrant.QueryAllowancesByGranterResponse
+	err := c.QueryFeeGrantWithContext(ctx, func(ctx context.Context, queryClient feegrant.QueryClient) error {
+		var err error
+		resp, err = queryClient.AllowancesByGranter(ctx, &feegrant.QueryAllowancesByGranterRequest{
+			Granter:    granter,
+			Pagination: pageRequest,
+		})
+		return err
+	}, opts...)
+
+	return resp, err
+}
+
+// WalkFeeAllowancesByGranter calls f for every fee allowance given by granter, fetching the allowances page by page
+func (c *QueryClient) WalkFeeAllowancesByGranter(ctx context.Context, granter string, f func(grant *feegrant.Grant) error, opts ...QueryOption) error {
+	return c.paginate(ctx, func(ctx context.Context, pageReq *query.PageRequest, opts ...QueryOption) ([]byte, error) {
+		resp, err := c.FeeAllowancesByGranterWithContext(ctx, granter, pageReq, opts...)
+		if err != nil {
+			return nil, err
+		}
+		for _, grant := range resp.Allowances {
+			if err := f(grant); err != nil {
+				return nil, err
+			}
+		}
+		return nextKey(resp.Pagination), nil
+	}, opts...)
+}
@@ -0,0 +1,61 @@
+package query
+
+import (
+	"context"
+
+	feemarkettypes "github.com/evmos/ethermint/x/feemarket/types"
+)
+
+// QueryFeeMarket queries the FeeMarket module of the Lorenzo node
+// according to the given function
+func (c *QueryClient) QueryFeeMarket(f func(ctx context.Context, queryClient feemarkettypes.QueryClient) error, opts ...QueryOption) error {
+	return c.QueryFeeMarketWithContext(context.Background(), f, opts...)
+}
+
+// QueryFeeMarketWithContext is like QueryFeeMarket but bounds the query by the given context
+func (c *QueryClient) QueryFeeMarketWithContext(ctx context.Context, f func(ctx context.Context, queryClient feemarkettypes.QueryClient) error, opts ...QueryOption) error {
+	options := newQueryOptions(opts...)
+	queryCtx, cancel := c.getQueryContext(ctx, options)
+	defer cancel()
+
+	queryClient := feemarkettypes.NewQueryClient(c.getQueryConn(options))
+
+	return f(queryCtx, queryClient)
+}
+
+// FeeMarketParams queries feemarket module's parameters, including the global minimum gas price
+func (c *QueryClient) FeeMarketParams(opts ...QueryOption) (*feemarkettypes.QueryParamsResponse, error) {
+	return c.FeeMarketParamsWithContext(context.Background(), opts...)
+}
+
+// FeeMarketParamsWithContext is like FeeMarketParams but bounds the query by the given context
+func (c *QueryClient) FeeMarketParamsWithContext(ctx context.Context, opts ...QueryOption) (*feemarkettypes.QueryParamsResponse, error) {
+	var resp *feemarkettypes.QueryParamsResponse
+	err := c.QueryFeeMarketWithContext(ctx, func(ctx context.Context, queryClient feemarkettypes.QueryClient) error {
+		var err error
+		req := &feemarkettypes.QueryParamsRequest{}
+		resp, err = queryClient.Params(ctx, req)
+		return err
+	}, opts...)
+
+	return resp, err
+}
+
+// FeeMarketBaseFee queries the current EIP-1559 base fee.
+// The base fee is nil if the fee market does not enforce one.
+func (c *QueryClient) FeeMarketBaseFee(opts ...QueryOption) (*feemarkettypes.QueryBaseFeeResponse, error) {
+	return c.FeeMarketBaseFeeWithContext(context.Background(), opts...)
+}
+
+// FeeMarketBaseFeeWithContext is like FeeMarketBaseFee but bounds the query by the given context
+func (c *QueryClient) FeeMarketBaseFeeWithContext(ctx context.Context, opts ...QueryOption) (*feemarkettypes.QueryBaseFeeResponse, error) {
+	var resp *feemarkettypes.QueryBaseFeeResponse
+	err := c.QueryFeeMarketWithContext(ctx, func(ctx context.Context, queryClient feemarkettypes.QueryClient) error {
+		var err error
+		req := &feemarkettypes.QueryBaseFeeRequest{}
+		resp, err = queryClient.BaseFee(ctx, req)
+		return err
+	}, opts...)
+
+	return resp, err
+}
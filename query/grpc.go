@@ -0,0 +1,36 @@
+package query
+
+import (
+	"crypto/tls"
+
+	lorenzo "github.com/Lorenzo-Protocol/lorenzo/v3/app"
+	"github.com/cosmos/cosmos-sdk/codec"
+	serverconfig "github.com/cosmos/cosmos-sdk/server/config"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// DialGRPC opens a gRPC connection to the gRPC server of a Lorenzo node.
+// The connection is meant to be shared by all queries of a QueryClient,
+// which multiplex over it instead of going through the CometBFT RPC.
+func DialGRPC(addr string, useTLS bool) (*grpc.ClientConn, error) {
+	creds := insecure.NewCredentials()
+	if useTLS {
+		creds = credentials.NewTLS(&tls.Config{MinVersion: tls.VersionTLS12})
+	}
+
+	// use the codec of the Lorenzo app so that responses containing
+	// interfaces are unpacked the same way as with ABCI queries
+	encCfg := lorenzo.MakeEncodingConfig()
+
+	return grpc.Dial(
+		addr,
+		grpc.WithTransportCredentials(creds),
+		grpc.WithDefaultCallOptions(
+			grpc.ForceCodec(codec.NewProtoCodec(encCfg.InterfaceRegistry).GRPCCodec()),
+			grpc.MaxCallRecvMsgSize(serverconfig.DefaultGRPCMaxRecvMsgSize),
+			grpc.MaxCallSendMsgSize(serverconfig.DefaultGRPCMaxSendMsgSize),
+		),
+	)
+}
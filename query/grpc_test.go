@@ -0,0 +1,167 @@
+package query
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"testing"
+	"time"
+
+	lorenzo "github.com/Lorenzo-Protocol/lorenzo/v3/app"
+	plantypes "github.com/Lorenzo-Protocol/lorenzo/v3/x/plan/types"
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/codec"
+	grpctypes "github.com/cosmos/cosmos-sdk/types/grpc"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// fakePlanServer serves the plan params at the requested height, or at its latest height
+// if none is requested. With slow set, it only answers once the query is canceled.
+type fakePlanServer struct {
+	plantypes.UnimplementedQueryServer
+	latest    int64
+	slow      bool
+	requested []string
+}
+
+func (s *fakePlanServer) Params(ctx context.Context, _ *plantypes.QueryParamsRequest) (*plantypes.QueryParamsResponse, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	heights := md.Get(grpctypes.GRPCBlockHeightHeader)
+	s.requested = append(s.requested, heights...)
+	if s.slow {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+
+	height := s.latest
+	if len(heights) > 0 && heights[0] != "0" {
+		height, _ = strconv.ParseInt(heights[0], 10, 64)
+	}
+	if err := grpc.SetHeader(ctx, metadata.Pairs(grpctypes.GRPCBlockHeightHeader, strconv.FormatInt(height, 10))); err != nil {
+		return nil, err
+	}
+	return &plantypes.QueryParamsResponse{}, nil
+}
+
+// newGRPCTestClient returns a client querying server over gRPC, whose RPC client must not be used
+func newGRPCTestClient(t *testing.T, server *fakePlanServer, timeout time.Duration) (*QueryClient, *fakeABCINode) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	encCfg := lorenzo.MakeEncodingConfig()
+	grpcServer := grpc.NewServer(grpc.ForceServerCodec(codec.NewProtoCodec(encCfg.InterfaceRegistry).GRPCCodec()))
+	plantypes.RegisterQueryServer(grpcServer, server)
+	go func() {
+		_ = grpcServer.Serve(listener)
+	}()
+	t.Cleanup(grpcServer.Stop)
+
+	conn, err := DialGRPC(listener.Addr().String(), false)
+	require.NoError(t, err)
+	node := &fakeABCINode{}
+	c, err := NewWithClients(node, conn, timeout)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+	return c, node
+}
+
+func TestGRPCTransport(t *testing.T) {
+	server := &fakePlanServer{latest: 100}
+	c, node := newGRPCTestClient(t, server, time.Second)
+
+	for _, tc := range []struct {
+		opts      []QueryOption
+		requested string
+		served    int64
+	}{
+		{nil, "0", 100},
+		{[]QueryOption{WithHeight(42)}, "42", 42},
+	} {
+		server.requested = nil
+		var served int64
+		_, err := c.PlanParams(append(tc.opts, WithResponseHeight(&served))...)
+		require.NoError(t, err)
+		require.Equal(t, []string{tc.requested}, server.requested)
+		require.Equal(t, tc.served, served)
+	}
+
+	// module queries do not go through the RPC client once a gRPC connection is set
+	require.Empty(t, node.requested)
+}
+
+func TestGRPCTimeout(t *testing.T) {
+	c, _ := newGRPCTestClient(t, &fakePlanServer{slow: true}, 50*time.Millisecond)
+
+	// the query ends with the timeout of the client
+	_, err := c.PlanParams()
+	require.Equal(t, codes.DeadlineExceeded, status.Code(err))
+
+	// or with its context, if canceled first
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = c.PlanParamsWithContext(ctx)
+	require.Equal(t, codes.Canceled, status.Code(err))
+}
+
+func TestGetQueryContext(t *testing.T) {
+	timeout := time.Minute
+	c := &QueryClient{timeout: timeout}
+
+	for _, tc := range []struct {
+		name     string
+		deadline time.Duration
+		expected time.Duration
+	}{
+		{"timeout of the client", 0, timeout},
+		{"earlier deadline of the parent", time.Second, time.Second},
+		{"later deadline of the parent", time.Hour, timeout},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			parent := context.Background()
+			if tc.deadline > 0 {
+				var cancel context.CancelFunc
+				parent, cancel = context.WithTimeout(parent, tc.deadline)
+				defer cancel()
+			}
+
+			start := time.Now()
+			ctx, cancel := c.getQueryContext(parent, newQueryOptions(WithHeight(7)))
+			defer cancel()
+			deadline, ok := ctx.Deadline()
+			require.True(t, ok)
+			require.WithinDuration(t, start.Add(tc.expected), deadline, time.Second)
+
+			md, ok := metadata.FromOutgoingContext(ctx)
+			require.True(t, ok)
+			require.Equal(t, []string{"7"}, md.Get(grpctypes.GRPCBlockHeightHeader))
+		})
+	}
+}
+
+func TestGetQueryConn(t *testing.T) {
+	node := &fakeABCINode{}
+	c, err := NewWithClient(node, time.Second)
+	require.NoError(t, err)
+
+	// without gRPC connection, module queries are ABCI queries over the RPC client
+	require.Equal(t, client.Context{Client: node}, c.getQueryConn(DefaultQueryOptions()))
+
+	var served int64
+	conn, ok := c.getQueryConn(newQueryOptions(WithResponseHeight(&served))).(*heightRecordingConn)
+	require.True(t, ok)
+	require.Equal(t, client.Context{Client: node}, conn.ClientConn)
+
+	// with one, they are sent over it
+	c.GRPCConn, err = DialGRPC("127.0.0.1:1", false)
+	require.NoError(t, err)
+	defer c.GRPCConn.Close()
+	require.Same(t, c.GRPCConn, c.getQueryConn(DefaultQueryOptions()))
+	conn, ok = c.getQueryConn(newQueryOptions(WithResponseHeight(&served))).(*heightRecordingConn)
+	require.True(t, ok)
+	require.Same(t, c.GRPCConn, conn.ClientConn)
+}
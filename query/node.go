@@ -0,0 +1,23 @@
+package query
+
+import (
+	"context"
+
+	"github.com/cosmos/cosmos-sdk/client/grpc/node"
+)
+
+// NodeConfig queries the configuration of the Lorenzo node, such as its minimum gas prices
+func (c *QueryClient) NodeConfig(opts ...QueryOption) (*node.ConfigResponse, error) {
+	return c.NodeConfigWithContext(context.Background(), opts...)
+}
+
+// NodeConfigWithContext is like NodeConfig but bounds the query by the given context
+func (c *QueryClient) NodeConfigWithContext(ctx context.Context, opts ...QueryOption) (*node.ConfigResponse, error) {
+	options := newQueryOptions(opts...)
+	queryCtx, cancel := c.getQueryContext(ctx, options)
+	defer cancel()
+
+	queryClient := node.NewServiceClient(c.getQueryConn(options))
+
+	return queryClient.Config(queryCtx, &node.ConfigRequest{})
+}
@@ -0,0 +1,70 @@
+package query
+
+import (
+	"context"
+	"errors"
+
+	"github.com/cosmos/cosmos-sdk/types/query"
+)
+
+// ErrStopWalk can be returned by the function passed to a Walk* method
+// to end the walk early without the Walk* method returning an error
+var ErrStopWalk = errors.New("stop walk")
+
+// pageFetcher fetches the page described by pageReq, hands its items over
+// and returns the key of the next page, which is empty on the last page
+type pageFetcher func(ctx context.Context, pageReq *query.PageRequest, opts ...QueryOption) ([]byte, error)
+
+// paginate calls fetch for every page of a list query, following the next key
+// returned by the node until the last page is reached, ctx is done or fetch fails.
+// The first page starts at the key and uses the limit of the Pagination option.
+// If the PinHeight option is set and no Height is given, every page after the first one
+// is queried at the height the first page was served at.
+func (c *QueryClient) paginate(ctx context.Context, fetch pageFetcher, opts ...QueryOption) error {
+	options := newQueryOptions(opts...)
+	pageReq := &query.PageRequest{}
+	if options.Pagination != nil {
+		pageReq.Key = options.Pagination.Key
+		pageReq.Limit = options.Pagination.Limit
+		pageReq.Reverse = options.Pagination.Reverse
+	}
+
+	var servedHeight int64
+	pageOpts := append(append([]QueryOption{}, opts...), WithResponseHeight(&servedHeight))
+	for page := 0; ; page++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		nextKey, err := fetch(ctx, pageReq, pageOpts...)
+		if options.ResponseHeight != nil {
+			*options.ResponseHeight = servedHeight
+		}
+		if errors.Is(err, ErrStopWalk) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if len(nextKey) == 0 {
+			return nil
+		}
+
+		if page == 0 && options.PinHeight && options.Height == 0 {
+			pageOpts = append(pageOpts, WithHeight(servedHeight))
+		}
+		pageReq = &query.PageRequest{
+			Key:     nextKey,
+			Limit:   pageReq.Limit,
+			Reverse: pageReq.Reverse,
+		}
+	}
+}
+
+// nextKey returns the key of the next page, if any
+func nextKey(pageResp *query.PageResponse) []byte {
+	if pageResp == nil {
+		return nil
+	}
+	return pageResp.NextKey
+}
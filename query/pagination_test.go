@@ -0,0 +1,69 @@
+package query
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/types/query"
+	"github.com/stretchr/testify/require"
+)
+
+// fakePages serves three pages of two items each, the first one at height 10
+// and the following ones at height 11 unless a height is requested
+func fakePages(items *[]int, heights *[]int64) pageFetcher {
+	pages := map[string][]int{"": {1, 2}, "a": {3, 4}, "b": {5, 6}}
+	next := map[string]string{"": "a", "a": "b", "b": ""}
+	servedAt := int64(10)
+	return func(ctx context.Context, pageReq *query.PageRequest, opts ...QueryOption) ([]byte, error) {
+		options := newQueryOptions(opts...)
+		height := options.Height
+		if height == 0 {
+			height = servedAt
+			servedAt++
+		}
+		*heights = append(*heights, height)
+		*options.ResponseHeight = height
+
+		*items = append(*items, pages[string(pageReq.Key)]...)
+		return []byte(next[string(pageReq.Key)]), nil
+	}
+}
+
+func TestPaginate(t *testing.T) {
+	c := &QueryClient{}
+
+	var (
+		items   []int
+		heights []int64
+		served  int64
+	)
+	err := c.paginate(context.Background(), fakePages(&items, &heights), WithResponseHeight(&served))
+	require.NoError(t, err)
+	require.Equal(t, []int{1, 2, 3, 4, 5, 6}, items)
+	require.Equal(t, []int64{10, 11, 12}, heights)
+	require.Equal(t, int64(12), served)
+
+	items, heights = nil, nil
+	err = c.paginate(context.Background(), fakePages(&items, &heights), WithPinnedHeight())
+	require.NoError(t, err)
+	require.Equal(t, []int{1, 2, 3, 4, 5, 6}, items)
+	require.Equal(t, []int64{10, 10, 10}, heights)
+
+	items, heights = nil, nil
+	fetch := fakePages(&items, &heights)
+	err = c.paginate(context.Background(), func(ctx context.Context, pageReq *query.PageRequest, opts ...QueryOption) ([]byte, error) {
+		if string(pageReq.Key) == "b" {
+			return nil, ErrStopWalk
+		}
+		return fetch(ctx, pageReq, opts...)
+	})
+	require.NoError(t, err)
+	require.Equal(t, []int{1, 2, 3, 4}, items)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	items, heights = nil, nil
+	err = c.paginate(ctx, fakePages(&items, &heights))
+	require.ErrorIs(t, err, context.Canceled)
+	require.Empty(t, items)
+}
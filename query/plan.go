@@ -5,23 +5,32 @@ import (
 	"cosmossdk.io/math"
 
 	plantypes "github.com/Lorenzo-Protocol/lorenzo/v3/x/plan/types"
-	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/types/query"
 )
 
-func (c *QueryClient) QueryPlan(f func(ctx context.Context, queryClient plantypes.QueryClient) error) error {
-	ctx, cancel := c.getQueryContext()
+func (c *QueryClient) QueryPlan(f func(ctx context.Context, queryClient plantypes.QueryClient) error, opts ...QueryOption) error {
+	return c.QueryPlanWithContext(context.Background(), f, opts...)
+}
+
+// QueryPlanWithContext is like QueryPlan but bounds the query by the given context
+func (c *QueryClient) QueryPlanWithContext(ctx context.Context, f func(ctx context.Context, queryClient plantypes.QueryClient) error, opts ...QueryOption) error {
+	options := newQueryOptions(opts...)
+	queryCtx, cancel := c.getQueryContext(ctx, options)
 	defer cancel()
 
-	clientCtx := client.Context{Client: c.RPCClient}
-	queryClient := plantypes.NewQueryClient(clientCtx)
+	queryClient := plantypes.NewQueryClient(c.getQueryConn(options))
+
+	return f(queryCtx, queryClient)
+}
 
-	return f(ctx, queryClient)
+func (c *QueryClient) PlanParams(opts ...QueryOption) (*plantypes.QueryParamsResponse, error) {
+	return c.PlanParamsWithContext(context.Background(), opts...)
 }
 
-func (c *QueryClient) PlanParams() (*plantypes.QueryParamsResponse, error) {
+// PlanParamsWithContext is like PlanParams but bounds the query by the given context
+func (c *QueryClient) PlanParamsWithContext(ctx context.Context, opts ...QueryOption) (*plantypes.QueryParamsResponse, error) {
 	var resp *plantypes.QueryParamsResponse
-	err := c.QueryPlan(func(ctx context.Context, queryClient plantypes.QueryClient) error {
+	err := c.QueryPlanWithContext(ctx, func(ctx context.Context, queryClient plantypes.QueryClient) error {
 		req := &plantypes.QueryParamsRequest{}
 
 		var err error
@@ -31,7 +40,7 @@ func (c *QueryClient) PlanParams() (*plantypes.QueryParamsResponse, error) {
 		}
 
 		return nil
-	})
+	}, opts...)
 
 	if err != nil {
 		return nil, err
@@ -39,9 +48,14 @@ func (c *QueryClient) PlanParams() (*plantypes.QueryParamsResponse, error) {
 	return resp, nil
 }
 
-func (c *QueryClient) Plans(pageRequest *query.PageRequest) (*plantypes.QueryPlansResponse, error) {
+func (c *QueryClient) Plans(pageRequest *query.PageRequest, opts ...QueryOption) (*plantypes.QueryPlansResponse, error) {
+	return c.PlansWithContext(context.Background(), pageRequest, opts...)
+}
+
+// PlansWithContext is like Plans but bounds the query by the given context
+func (c *QueryClient) PlansWithContext(ctx context.Context, pageRequest *query.PageRequest, opts ...QueryOption) (*plantypes.QueryPlansResponse, error) {
 	var resp *plantypes.QueryPlansResponse
-	err := c.QueryPlan(func(ctx context.Context, queryClient plantypes.QueryClient) error {
+	err := c.QueryPlanWithContext(ctx, func(ctx context.Context, queryClient plantypes.QueryClient) error {
 		req := &plantypes.QueryPlansRequest{
 			Pagination: pageRequest,
 		}
@@ -53,7 +67,7 @@ func (c *QueryClient) Plans(pageRequest *query.PageRequest) (*plantypes.QueryPla
 		}
 
 		return nil
-	})
+	}, opts...)
 
 	if err != nil {
 		return nil, err
@@ -61,15 +75,20 @@ func (c *QueryClient) Plans(pageRequest *query.PageRequest) (*plantypes.QueryPla
 	return resp, nil
 }
 
-func (c *QueryClient) Plan(planId uint64) (plantypes.Plan, error) {
+func (c *QueryClient) Plan(planId uint64, opts ...QueryOption) (plantypes.Plan, error) {
+	return c.PlanWithContext(context.Background(), planId, opts...)
+}
+
+// PlanWithContext is like Plan but bounds the query by the given context
+func (c *QueryClient) PlanWithContext(ctx context.Context, planId uint64, opts ...QueryOption) (plantypes.Plan, error) {
 	var resp *plantypes.QueryPlanResponse
-	err := c.QueryPlan(func(ctx context.Context, queryClient plantypes.QueryClient) error {
+	err := c.QueryPlanWithContext(ctx, func(ctx context.Context, queryClient plantypes.QueryClient) error {
 		var err error
 		resp, err = queryClient.Plan(ctx, &plantypes.QueryPlanRequest{
 			Id: planId,
 		})
 		return err
-	})
+	}, opts...)
 
 	if err != nil {
 		return plantypes.Plan{}, err
@@ -78,9 +97,14 @@ func (c *QueryClient) Plan(planId uint64) (plantypes.Plan, error) {
 	return resp.Plan, nil
 }
 
-func (c *QueryClient) ClaimLeafNode(planId uint64, roundId math.Int, leafNode string) (bool, error) {
+func (c *QueryClient) ClaimLeafNode(planId uint64, roundId math.Int, leafNode string, opts ...QueryOption) (bool, error) {
+	return c.ClaimLeafNodeWithContext(context.Background(), planId, roundId, leafNode, opts...)
+}
+
+// ClaimLeafNodeWithContext is like ClaimLeafNode but bounds the query by the given context
+func (c *QueryClient) ClaimLeafNodeWithContext(ctx context.Context, planId uint64, roundId math.Int, leafNode string, opts ...QueryOption) (bool, error) {
 	var resp *plantypes.QueryClaimLeafNodeResponse
-	err := c.QueryPlan(func(ctx context.Context, queryClient plantypes.QueryClient) error {
+	err := c.QueryPlanWithContext(ctx, func(ctx context.Context, queryClient plantypes.QueryClient) error {
 		var err error
 		resp, err = queryClient.ClaimLeafNode(ctx, &plantypes.QueryClaimLeafNodeRequest{
 			Id:       planId,
@@ -88,7 +112,7 @@ func (c *QueryClient) ClaimLeafNode(planId uint64, roundId math.Int, leafNode st
 			LeafNode: leafNode,
 		})
 		return err
-	})
+	}, opts...)
 
 	if err != nil {
 		return false, err
@@ -96,3 +120,33 @@ func (c *QueryClient) ClaimLeafNode(planId uint64, roundId math.Int, leafNode st
 
 	return resp.Success, nil
 }
+
+// WalkPlans calls f for every plan, fetching the plans page by page
+func (c *QueryClient) WalkPlans(ctx context.Context, f func(plan plantypes.Plan) error, opts ...QueryOption) error {
+	return c.paginate(ctx, func(ctx context.Context, pageReq *query.PageRequest, opts ...QueryOption) ([]byte, error) {
+		resp, err := c.PlansWithContext(ctx, pageReq, opts...)
+		if err != nil {
+			return nil, err
+		}
+		for _, plan := range resp.Plans {
+			if err := f(plan); err != nil {
+				return nil, err
+			}
+		}
+		return nextKey(resp.Pagination), nil
+	}, opts...)
+}
+
+// AllPlans returns all plans, following the pagination of the plans query
+func (c *QueryClient) AllPlans(ctx context.Context, opts ...QueryOption) ([]plantypes.Plan, error) {
+	var plans []plantypes.Plan
+	err := c.WalkPlans(ctx, func(plan plantypes.Plan) error {
+		plans = append(plans, plan)
+		return nil
+	}, opts...)
+
+	if err != nil {
+		return nil, err
+	}
+	return plans, nil
+}
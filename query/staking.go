@@ -3,31 +3,40 @@ package query
 import (
 	"context"
 
-	"github.com/cosmos/cosmos-sdk/client"
 	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
 )
 
 // QueryStaking queries the Staking module of the Lorenzo node
 // according to the given function
-func (c *QueryClient) QueryStaking(f func(ctx context.Context, queryClient stakingtypes.QueryClient) error) error {
-	ctx, cancel := c.getQueryContext()
+func (c *QueryClient) QueryStaking(f func(ctx context.Context, queryClient stakingtypes.QueryClient) error, opts ...QueryOption) error {
+	return c.QueryStakingWithContext(context.Background(), f, opts...)
+}
+
+// QueryStakingWithContext is like QueryStaking but bounds the query by the given context
+func (c *QueryClient) QueryStakingWithContext(ctx context.Context, f func(ctx context.Context, queryClient stakingtypes.QueryClient) error, opts ...QueryOption) error {
+	options := newQueryOptions(opts...)
+	queryCtx, cancel := c.getQueryContext(ctx, options)
 	defer cancel()
 
-	clientCtx := client.Context{Client: c.RPCClient}
-	queryClient := stakingtypes.NewQueryClient(clientCtx)
+	queryClient := stakingtypes.NewQueryClient(c.getQueryConn(options))
 
-	return f(ctx, queryClient)
+	return f(queryCtx, queryClient)
 }
 
 // StakingParams queries btccheckpoint module's parameters via ChainClient
-func (c *QueryClient) StakingParams() (*stakingtypes.QueryParamsResponse, error) {
+func (c *QueryClient) StakingParams(opts ...QueryOption) (*stakingtypes.QueryParamsResponse, error) {
+	return c.StakingParamsWithContext(context.Background(), opts...)
+}
+
+// StakingParamsWithContext is like StakingParams but bounds the query by the given context
+func (c *QueryClient) StakingParamsWithContext(ctx context.Context, opts ...QueryOption) (*stakingtypes.QueryParamsResponse, error) {
 	var resp *stakingtypes.QueryParamsResponse
-	err := c.QueryStaking(func(ctx context.Context, queryClient stakingtypes.QueryClient) error {
+	err := c.QueryStakingWithContext(ctx, func(ctx context.Context, queryClient stakingtypes.QueryClient) error {
 		var err error
 		req := &stakingtypes.QueryParamsRequest{}
 		resp, err = queryClient.Params(ctx, req)
 		return err
-	})
+	}, opts...)
 
 	return resp, err
 }
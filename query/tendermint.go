@@ -9,52 +9,92 @@ import (
 
 // GetStatus returns the status of the tendermint node
 func (c *QueryClient) GetStatus() (*coretypes.ResultStatus, error) {
-	ctx, cancel := c.getQueryContext()
+	return c.GetStatusWithContext(context.Background())
+}
+
+// GetStatusWithContext is like GetStatus but bounds the query by the given context
+func (c *QueryClient) GetStatusWithContext(ctx context.Context) (*coretypes.ResultStatus, error) {
+	queryCtx, cancel := c.getQueryContext(ctx, DefaultQueryOptions())
 	defer cancel()
 
-	return c.RPCClient.Status(ctx)
+	return c.RPCClient.Status(queryCtx)
 }
 
 // GetBlock returns the tendermint block at a specific height
 func (c *QueryClient) GetBlock(height int64) (*coretypes.ResultBlock, error) {
-	ctx, cancel := c.getQueryContext()
+	return c.GetBlockWithContext(context.Background(), height)
+}
+
+// GetBlockWithContext is like GetBlock but bounds the query by the given context
+func (c *QueryClient) GetBlockWithContext(ctx context.Context, height int64) (*coretypes.ResultBlock, error) {
+	queryCtx, cancel := c.getQueryContext(ctx, DefaultQueryOptions())
 	defer cancel()
 
-	return c.RPCClient.Block(ctx, &height)
+	return c.RPCClient.Block(queryCtx, &height)
 }
 
 // BlockSearch searches for blocks satisfying the events specified on the events list
 func (c *QueryClient) BlockSearch(events []string, page *int, perPage *int, orderBy string) (*coretypes.ResultBlockSearch, error) {
-	ctx, cancel := c.getQueryContext()
+	return c.BlockSearchWithContext(context.Background(), events, page, perPage, orderBy)
+}
+
+// BlockSearchWithContext is like BlockSearch but bounds the query by the given context
+func (c *QueryClient) BlockSearchWithContext(ctx context.Context, events []string, page *int, perPage *int, orderBy string) (*coretypes.ResultBlockSearch, error) {
+	queryCtx, cancel := c.getQueryContext(ctx, DefaultQueryOptions())
 	defer cancel()
 
-	return c.RPCClient.BlockSearch(ctx, strings.Join(events, " AND "), page, perPage, orderBy)
+	return c.RPCClient.BlockSearch(queryCtx, strings.Join(events, " AND "), page, perPage, orderBy)
 }
 
 // TxSearch searches for transactions satisfying the events specified on the events list
 func (c *QueryClient) TxSearch(events []string, prove bool, page *int, perPage *int, orderBy string) (*coretypes.ResultTxSearch, error) {
-	ctx, cancel := c.getQueryContext()
+	return c.TxSearchWithContext(context.Background(), events, prove, page, perPage, orderBy)
+}
+
+// TxSearchWithContext is like TxSearch but bounds the query by the given context
+func (c *QueryClient) TxSearchWithContext(ctx context.Context, events []string, prove bool, page *int, perPage *int, orderBy string) (*coretypes.ResultTxSearch, error) {
+	queryCtx, cancel := c.getQueryContext(ctx, DefaultQueryOptions())
 	defer cancel()
 
-	return c.RPCClient.TxSearch(ctx, strings.Join(events, " AND "), prove, page, perPage, orderBy)
+	return c.RPCClient.TxSearch(queryCtx, strings.Join(events, " AND "), prove, page, perPage, orderBy)
 }
 
 // GetTx returns the transaction with the specified hash
 func (c *QueryClient) GetTx(hash []byte) (*coretypes.ResultTx, error) {
-	ctx, cancel := c.getQueryContext()
+	return c.GetTxWithContext(context.Background(), hash)
+}
+
+// GetTxWithContext is like GetTx but bounds the query by the given context
+func (c *QueryClient) GetTxWithContext(ctx context.Context, hash []byte) (*coretypes.ResultTx, error) {
+	queryCtx, cancel := c.getQueryContext(ctx, DefaultQueryOptions())
 	defer cancel()
 
-	return c.RPCClient.Tx(ctx, hash, false)
+	return c.RPCClient.Tx(queryCtx, hash, false)
 }
 
 func (c *QueryClient) Subscribe(subscriber, query string, outCapacity ...int) (out <-chan coretypes.ResultEvent, err error) {
-	return c.RPCClient.Subscribe(context.Background(), subscriber, query, outCapacity...)
+	return c.SubscribeWithContext(context.Background(), subscriber, query, outCapacity...)
+}
+
+// SubscribeWithContext is like Subscribe but sends the request with the given context
+func (c *QueryClient) SubscribeWithContext(ctx context.Context, subscriber, query string, outCapacity ...int) (out <-chan coretypes.ResultEvent, err error) {
+	return c.RPCClient.Subscribe(ctx, subscriber, query, outCapacity...)
 }
 
 func (c *QueryClient) Unsubscribe(subscriber, query string) error {
-	return c.RPCClient.Unsubscribe(context.Background(), subscriber, query)
+	return c.UnsubscribeWithContext(context.Background(), subscriber, query)
+}
+
+// UnsubscribeWithContext is like Unsubscribe but sends the request with the given context
+func (c *QueryClient) UnsubscribeWithContext(ctx context.Context, subscriber, query string) error {
+	return c.RPCClient.Unsubscribe(ctx, subscriber, query)
 }
 
 func (c *QueryClient) UnsubscribeAll(subscriber string) error {
-	return c.RPCClient.UnsubscribeAll(context.Background(), subscriber)
+	return c.UnsubscribeAllWithContext(context.Background(), subscriber)
+}
+
+// UnsubscribeAllWithContext is like UnsubscribeAll but sends the request with the given context
+func (c *QueryClient) UnsubscribeAllWithContext(ctx context.Context, subscriber string) error {
+	return c.RPCClient.UnsubscribeAll(ctx, subscriber)
 }
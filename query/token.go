@@ -4,67 +4,128 @@ import (
 	"context"
 
 	"github.com/Lorenzo-Protocol/lorenzo/v3/x/token/types"
-	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/types/query"
+
+	"github.com/Lorenzo-Protocol/lorenzo-sdk/v3/address"
 )
 
-func (c *QueryClient) QueryToken(f func(ctx context.Context, client types.QueryClient) error) error {
-	ctx, cancel := c.getQueryContext()
+func (c *QueryClient) QueryToken(f func(ctx context.Context, client types.QueryClient) error, opts ...QueryOption) error {
+	return c.QueryTokenWithContext(context.Background(), f, opts...)
+}
+
+// QueryTokenWithContext is like QueryToken but bounds the query by the given context
+func (c *QueryClient) QueryTokenWithContext(ctx context.Context, f func(ctx context.Context, client types.QueryClient) error, opts ...QueryOption) error {
+	options := newQueryOptions(opts...)
+	queryCtx, cancel := c.getQueryContext(ctx, options)
 	defer cancel()
 
-	clientCtx := client.Context{Client: c.RPCClient}
-	queryClient := types.NewQueryClient(clientCtx)
+	queryClient := types.NewQueryClient(c.getQueryConn(options))
+
+	return f(queryCtx, queryClient)
+}
 
-	return f(ctx, queryClient)
+func (c *QueryClient) TokenPairs(pageRequest *query.PageRequest, opts ...QueryOption) (*types.QueryTokenPairsResponse, error) {
+	return c.TokenPairsWithContext(context.Background(), pageRequest, opts...)
 }
 
-func (c *QueryClient) TokenPairs(pageRequest *query.PageRequest) (*types.QueryTokenPairsResponse, error) {
+// TokenPairsWithContext is like TokenPairs but bounds the query by the given context
+func (c *QueryClient) TokenPairsWithContext(ctx context.Context, pageRequest *query.PageRequest, opts ...QueryOption) (*types.QueryTokenPairsResponse, error) {
 	var resp *types.QueryTokenPairsResponse
-	err := c.QueryToken(func(ctx context.Context, queryClient types.QueryClient) error {
+	err := c.QueryTokenWithContext(ctx, func(ctx context.Context, queryClient types.QueryClient) error {
 		var err error
 		resp, err = queryClient.TokenPairs(ctx, &types.QueryTokenPairsRequest{
 			Pagination: pageRequest,
 		})
 		return err
-	})
+	}, opts...)
 
 	return resp, err
 }
 
-func (c *QueryClient) TokenPair(tokenAddressOrDenom string) (*types.QueryTokenPairResponse, error) {
+func (c *QueryClient) TokenPair(tokenAddressOrDenom string, opts ...QueryOption) (*types.QueryTokenPairResponse, error) {
+	return c.TokenPairWithContext(context.Background(), tokenAddressOrDenom, opts...)
+}
+
+// TokenPairWithContext is like TokenPair but bounds the query by the given context
+func (c *QueryClient) TokenPairWithContext(ctx context.Context, tokenAddressOrDenom string, opts ...QueryOption) (*types.QueryTokenPairResponse, error) {
 	var resp *types.QueryTokenPairResponse
-	err := c.QueryToken(func(ctx context.Context, queryClient types.QueryClient) error {
+	err := c.QueryTokenWithContext(ctx, func(ctx context.Context, queryClient types.QueryClient) error {
 		var err error
 		resp, err = queryClient.TokenPair(ctx, &types.QueryTokenPairRequest{
 			Token: tokenAddressOrDenom,
 		})
 		return err
-	})
+	}, opts...)
 
 	return resp, err
 }
 
-func (c *QueryClient) Balance(accountAddress string, tokenAddressOrDenom string) (*types.QueryBalanceResponse, error) {
+// Balance queries the balance of the account with the given lrz1... or 0x... address in the given token pair
+func (c *QueryClient) Balance(accountAddress string, tokenAddressOrDenom string, opts ...QueryOption) (*types.QueryBalanceResponse, error) {
+	return c.BalanceWithContext(context.Background(), accountAddress, tokenAddressOrDenom, opts...)
+}
+
+// BalanceWithContext is like Balance but bounds the query by the given context
+func (c *QueryClient) BalanceWithContext(ctx context.Context, accountAddress string, tokenAddressOrDenom string, opts ...QueryOption) (*types.QueryBalanceResponse, error) {
+	if err := address.ValidateAccount(accountAddress); err != nil {
+		return nil, err
+	}
+
 	var resp *types.QueryBalanceResponse
-	err := c.QueryToken(func(ctx context.Context, queryClient types.QueryClient) error {
+	err := c.QueryTokenWithContext(ctx, func(ctx context.Context, queryClient types.QueryClient) error {
 		var err error
 		resp, err = queryClient.Balance(ctx, &types.QueryBalanceRequest{
 			AccountAddress: accountAddress,
 			Token:          tokenAddressOrDenom,
 		})
 		return err
-	})
+	}, opts...)
 
 	return resp, err
 }
 
-func (c *QueryClient) TokenParams() (*types.QueryParamsResponse, error) {
+func (c *QueryClient) TokenParams(opts ...QueryOption) (*types.QueryParamsResponse, error) {
+	return c.TokenParamsWithContext(context.Background(), opts...)
+}
+
+// TokenParamsWithContext is like TokenParams but bounds the query by the given context
+func (c *QueryClient) TokenParamsWithContext(ctx context.Context, opts ...QueryOption) (*types.QueryParamsResponse, error) {
 	var resp *types.QueryParamsResponse
-	err := c.QueryToken(func(ctx context.Context, queryClient types.QueryClient) error {
+	err := c.QueryTokenWithContext(ctx, func(ctx context.Context, queryClient types.QueryClient) error {
 		var err error
 		resp, err = queryClient.Params(ctx, &types.QueryParamsRequest{})
 		return err
-	})
+	}, opts...)
 
 	return resp, err
 }
+
+// WalkTokenPairs calls f for every token pair, fetching the token pairs page by page
+func (c *QueryClient) WalkTokenPairs(ctx context.Context, f func(tokenPair types.TokenPair) error, opts ...QueryOption) error {
+	return c.paginate(ctx, func(ctx context.Context, pageReq *query.PageRequest, opts ...QueryOption) ([]byte, error) {
+		resp, err := c.TokenPairsWithContext(ctx, pageReq, opts...)
+		if err != nil {
+			return nil, err
+		}
+		for _, tokenPair := range resp.TokenPairs {
+			if err := f(tokenPair); err != nil {
+				return nil, err
+			}
+		}
+		return nextKey(resp.Pagination), nil
+	}, opts...)
+}
+
+// AllTokenPairs returns all token pairs, following the pagination of the token pairs query
+func (c *QueryClient) AllTokenPairs(ctx context.Context, opts ...QueryOption) ([]types.TokenPair, error) {
+	var tokenPairs []types.TokenPair
+	err := c.WalkTokenPairs(ctx, func(tokenPair types.TokenPair) error {
+		tokenPairs = append(tokenPairs, tokenPair)
+		return nil
+	}, opts...)
+
+	if err != nil {
+		return nil, err
+	}
+	return tokenPairs, nil
+}